@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	arrowDamage    = 4
+	shootOdds      = 5
+	loseArrowsOdds = 5
+	maxRange       = 2
+)
+
+// Arrows are easier to dodge the further away the archer is, and the less
+// light there is to aim by. In pitch darkness half of them go astray.
+func hitChance(distance int, light int) float64 {
+	chance := 0.6 / float64(distance)
+	return chance * (0.5 + 0.5*float64(light)/fullLight)
+}
+
+// inSight returns the rooms in a straight line from the player in the given
+// direction that can be seen through open passages, nearest first.
+func inSight(dir string) []RoomCoord {
+	sight := []RoomCoord{}
+	coords := RoomCoord{x: x, y: y, z: z}
+	dx, dy, dz := dirOffset(dir)
+
+	for distance := 1; distance <= maxRange; distance++ {
+		next := RoomCoord{x: coords.x + dx, y: coords.y + dy, z: coords.z + dz}
+
+		if !world.room(coords).exits.getExit(dir) || !world.hasRoom(next) {
+			break
+		}
+
+		if !world.room(next).exits.getExit(opposite(dir)) {
+			break
+		}
+
+		sight = append(sight, next)
+		coords = next
+	}
+
+	return sight
+}
+
+func skeletonsShoot() {
+	here := getRoom(x, y, z, false)
+
+	for _, dir := range directions {
+		for i, coords := range inSight(dir) {
+			for _, e := range world.room(coords).entities {
+				if e.species != "a skeleton" || !e.hostile || rand.Intn(shootOdds) != 0 {
+					continue
+				}
+
+				if rand.Float64() < hitChance(i+1, lightLevel(here)) {
+					fmt.Printf("An arrow flies in from %s and hits you.\n", fromDir(dir))
+					attacked(e.kind().damage)
+				} else {
+					fmt.Printf("An arrow whizzes past you from %s.\n", fromDir(dir))
+				}
+
+				return
+			}
+		}
+	}
+}
+
+func fromDir(dir string) string {
+	switch dir {
+	case "up":
+		return "above"
+	case "down":
+		return "below"
+	default:
+		return "the " + dir
+	}
+}
+
+func shootComm(vals []string) {
+	var target string
+	var dir string
+
+	if len(vals) == 0 {
+		target = ""
+		dir = ""
+	} else if len(vals) == 1 {
+		target = vals[0]
+		dir = ""
+	} else {
+		target = vals[0]
+		dir = vals[1]
+	}
+
+	if target == "" {
+		fail("Shoot what?")
+		return
+	}
+
+	if _, ok := inventory["a bow"]; !ok {
+		fail("You don't have a bow.")
+		return
+	}
+
+	if _, ok := inventory["some arrows"]; !ok {
+		fail("You don't have any arrows.")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	distance := 0
+
+	if dir != "" {
+		sight := inSight(dir)
+		coords = RoomCoord{}
+		found := false
+
+		for i, c := range sight {
+			if _, ok := findEntity(world.room(c), target); ok {
+				coords = c
+				distance = i + 1
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			failf("You can't see any %s to the %s.\n", target, dir)
+			return
+		}
+	}
+
+	room := world.room(coords)
+	i, ok := findEntity(room, target)
+
+	if !ok {
+		failf("You don't see any %s here.\n", target)
+		return
+	}
+
+	if rand.Intn(loseArrowsOdds) == 0 {
+		delete(inventory, "some arrows")
+		defer fmt.Println("That was your last arrow.")
+	}
+
+	e := &room.entities[i]
+
+	if distance > 0 && rand.Float64() >= hitChance(distance, lightLevel(coords)) {
+		fmt.Printf("Your arrow misses the %s.\n", e.alias())
+		return
+	}
+
+	e.health -= arrowDamage
+
+	if e.health <= 0 {
+		dead := room.removeEntity(i)
+		fmt.Printf("Your arrow kills the %s.\n", dead.alias())
+
+		if !dead.baby() {
+			dropLoot(&room, dead, dead.kind().drops)
+		}
+	} else {
+		fmt.Printf("Your arrow hits the %s.\n", e.alias())
+
+		if e.kind().provoked && !e.hostile {
+			e.hostile = true
+			fmt.Printf("The %s growls.\n", e.alias())
+		}
+	}
+
+	world.setRoom(coords, room)
+}
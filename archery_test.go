@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHitChance(t *testing.T) {
+	if hitChance(2, fullLight) >= hitChance(1, fullLight) {
+		t.Error("arrows are no easier to dodge from further away")
+	}
+
+	if hitChance(1, 0) >= hitChance(1, fullLight) {
+		t.Error("arrows are no easier to dodge in the dark")
+	}
+
+	if got, want := hitChance(1, 0), hitChance(1, fullLight)/2; got != want {
+		t.Errorf("hitChance in pitch darkness = %v, want %v", got, want)
+	}
+}
+
+func TestInSight(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	north := connect(here, "north")
+	further := connect(north, "north")
+	connect(further, "north")
+
+	if got, want := inSight("north"), []RoomCoord{north, further}[:maxRange]; !reflect.DeepEqual(got, want) {
+		t.Errorf("inSight(north) = %v, want %v", got, want)
+	}
+
+	room := world.room(north)
+	room.exits.setExit("north", false)
+	world.setRoom(north, room)
+
+	if got, want := inSight("north"), []RoomCoord{north}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inSight(north) past a dead end = %v, want %v", got, want)
+	}
+
+	if got := inSight("south"); len(got) != 0 {
+		t.Errorf("inSight(south) with no way south = %v, want nothing", got)
+	}
+}
+
+func TestSkeletonsShoot(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	north := connect(here, "north")
+	further := connect(north, "north")
+	addEntities(further, "a skeleton")
+
+	for i := 0; i < 200 && health == maxHealth; i++ {
+		skeletonsShoot()
+	}
+
+	if health == maxHealth {
+		t.Errorf("a skeleton %d rooms away never hit the player", maxRange)
+	}
+
+	health = maxHealth
+	room := world.room(north)
+	room.exits.setExit("north", false)
+	world.setRoom(north, room)
+
+	for i := 0; i < 200; i++ {
+		skeletonsShoot()
+	}
+
+	if health != maxHealth {
+		t.Error("a skeleton shot the player from out of sight")
+	}
+}
+
+func TestShoot(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	north := connect(here, "north")
+	addEntities(here, "a zombie")
+	addEntities(north, "a zombie")
+	give("a bow")
+
+	shootComm([]string{"zombie"})
+
+	if world.room(here).entities[0].health != species["a zombie"].health || actionTurns != 0 {
+		t.Fatal("shot a zombie without any arrows")
+	}
+
+	for i := 0; i < 100 && len(world.room(here).entities) > 0; i++ {
+		give("some arrows")
+		shootComm([]string{"zombie"})
+
+		if e := world.room(here).entities; len(e) > 0 && e[0].health != species["a zombie"].health-arrowDamage*(i+1) {
+			t.Fatal("missed a zombie in the same room")
+		}
+	}
+
+	if len(world.room(here).entities) > 0 {
+		t.Fatal("couldn't shoot the zombie dead")
+	}
+
+	for i := 0; i < 100 && len(world.room(north).entities) > 0; i++ {
+		give("some arrows")
+		shootComm([]string{"zombie", "north"})
+	}
+
+	if len(world.room(north).entities) > 0 {
+		t.Error("couldn't shoot the zombie in the next room")
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+)
+
+var (
+	slots = []Slot{
+		Head, Chest, Legs, Feet,
+	}
+	// equipment is what the player is wearing. Each piece keeps its own
+	// durability, so it wears down whether it's worn, carried or stored.
+	equipment = map[string]Item{}
+)
+
+func slotName(slot Slot) string {
+	switch slot {
+	case Head:
+		return "head"
+	case Chest:
+		return "chest"
+	case Legs:
+		return "legs"
+	case Feet:
+		return "feet"
+	default:
+		return ""
+	}
+}
+
+func wearing(slot Slot) (string, bool) {
+	for name, item := range equipment {
+		if item.slot == slot {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func armorDefense() int {
+	defense := 0
+
+	for _, item := range equipment {
+		defense += item.defense
+	}
+
+	return defense
+}
+
+// attacked is hurt for damage dealt by monsters, which armor soaks up part of
+// at the cost of some durability.
+func attacked(damage int) {
+	defense := armorDefense()
+	damage -= damage * defense / 20
+
+	for _, slot := range slots {
+		name, ok := wearing(slot)
+
+		if !ok {
+			continue
+		}
+
+		iItem := equipment[name]
+		iItem.durability -= 1
+
+		if iItem.durability <= 0 {
+			delete(equipment, name)
+			fmt.Printf("Your %s breaks.\n", iItem.aliases[0])
+		} else {
+			equipment[name] = iItem
+		}
+	}
+
+	if damage < 1 {
+		damage = 1
+	}
+
+	hurt(damage)
+}
+
+func wearComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		fail("Wear what?")
+		return
+	}
+
+	name, ok := findItem(inventory, item)
+
+	if !ok {
+		failf("You don't have a %s.\n", item)
+		return
+	}
+
+	iItem := inventory[name]
+
+	if iItem.slot == NoSlot {
+		failf("You can't wear %s.\n", name)
+		return
+	}
+
+	if worn, ok := wearing(iItem.slot); ok {
+		inventory[worn] = equipment[worn]
+		delete(equipment, worn)
+		fmt.Printf("You take off %s.\n", worn)
+	}
+
+	delete(inventory, name)
+	equipment[name] = iItem
+	fmt.Printf("You put on %s.\n", name)
+}
+
+func removeComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		fail("Remove what?")
+		return
+	}
+
+	for _, slot := range slots {
+		name, ok := wearing(slot)
+
+		if !ok || (name != item && !hasAlias(items[name].aliases, item)) {
+			continue
+		}
+
+		inventory[name] = equipment[name]
+		delete(equipment, name)
+		fmt.Printf("You take off %s.\n", name)
+		return
+	}
+
+	failf("You aren't wearing %s.\n", item)
+}
+
+func equipmentComm(_ []string) {
+	worn := []string{}
+
+	for _, slot := range slots {
+		if name, ok := wearing(slot); ok {
+			worn = append(worn, fmt.Sprintf("%s on your %s", name, slotName(slot)))
+		}
+	}
+
+	if len(worn) == 0 {
+		fail("You aren't wearing any armor.")
+		return
+	}
+
+	fmt.Printf("You are wearing %s.\n", itemizeStr(worn))
+}
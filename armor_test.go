@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestWear(t *testing.T) {
+	newGame(t)
+	give("a leather helmet", "an iron helmet", "some iron boots")
+
+	wearComm([]string{"leather helmet"})
+	wearComm([]string{"boots"})
+	wearComm([]string{"iron helmet"})
+
+	if name, _ := wearing(Head); name != "an iron helmet" {
+		t.Errorf("wearing %q on the head, want the iron helmet", name)
+	}
+
+	if _, ok := inventory["a leather helmet"]; !ok {
+		t.Error("the leather helmet wasn't put back in the inventory")
+	}
+
+	if name, _ := wearing(Feet); name != "some iron boots" {
+		t.Errorf("wearing %q on the feet, want the iron boots", name)
+	}
+
+	removeComm([]string{"helmet"})
+
+	if _, ok := wearing(Head); ok {
+		t.Error("still wearing a helmet after taking it off")
+	}
+
+	if _, ok := inventory["an iron helmet"]; !ok {
+		t.Error("the iron helmet wasn't put back in the inventory")
+	}
+
+	wearComm([]string{"coal"})
+
+	if len(equipment) != 1 {
+		t.Errorf("equipment after trying to wear coal = %v", equipment)
+	}
+}
+
+func TestArmorSoaksDamage(t *testing.T) {
+	newGame(t)
+	attacked(8)
+	bare := maxHealth - health
+
+	health = maxHealth
+	give("an iron helmet", "an iron chestplate", "some iron leggings", "some iron boots")
+
+	for _, name := range []string{"an iron helmet", "an iron chestplate", "some iron leggings", "some iron boots"} {
+		wearComm([]string{name})
+	}
+
+	attacked(8)
+
+	if armored := maxHealth - health; armored >= bare {
+		t.Errorf("took %d damage in iron armor, %d without", armored, bare)
+	}
+
+	health = maxHealth
+	equipment = map[string]Item{}
+	give("a diamond helmet", "a diamond chestplate", "some diamond leggings", "some diamond boots")
+
+	for _, name := range []string{"a diamond helmet", "a diamond chestplate", "some diamond leggings", "some diamond boots"} {
+		wearComm([]string{name})
+	}
+
+	attacked(1)
+
+	if health != maxHealth-1 {
+		t.Errorf("took %d damage from a 1 damage hit in diamond armor, want at least 1", maxHealth-health)
+	}
+}
+
+func TestArmorWears(t *testing.T) {
+	newGame(t)
+	helmet := items["a leather helmet"]
+	helmet.durability = 2
+	inventory["a leather helmet"] = helmet
+	wearComm([]string{"helmet"})
+
+	attacked(1)
+
+	if got := equipment["a leather helmet"].durability; got != 1 {
+		t.Errorf("helmet durability after a hit = %d, want 1", got)
+	}
+
+	removeComm([]string{"helmet"})
+
+	if got := inventory["a leather helmet"].durability; got != 1 {
+		t.Errorf("helmet durability after taking it off = %d, want 1", got)
+	}
+
+	wearComm([]string{"helmet"})
+	attacked(1)
+
+	if _, ok := wearing(Head); ok {
+		t.Error("the helmet didn't break")
+	}
+
+	if _, ok := inventory["a leather helmet"]; ok {
+		t.Error("the broken helmet went back to the inventory")
+	}
+}
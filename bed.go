@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+)
+
+func sleepComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if _, ok := room.items["a bed"]; !ok {
+		fail("There's no bed here.")
+		return
+	}
+
+	if isSunny() || int(getTimeOfDay()) == len(dayCycle) {
+		fail("You can only sleep at night.")
+		return
+	}
+
+	if hasMonster(room) {
+		fail("You can't sleep with monsters nearby.")
+		return
+	}
+
+	cycle := len(dayCycle) * 3
+	sunrise := (len(dayCycle) - 1) * 3
+	turn += sunrise - turn%cycle
+	timeInRoom = 0
+	fmt.Println("You sleep through the night.")
+	fmt.Println(dayCycle[int(getTimeOfDay())-1])
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSleep(t *testing.T) {
+	here := newGame(t)
+	put(here, "a bed")
+
+	sleepComm([]string{})
+
+	if turn != 0 || actionTurns != 0 {
+		t.Errorf("slept through the day, turn = %d", turn)
+	}
+
+	night := len(dayCycle)*3 + 30
+	turn = night
+	actionTurns = 1
+	sleepComm([]string{})
+
+	if turn <= night || int(getTimeOfDay()) != len(dayCycle) {
+		t.Errorf("after sleeping the time of day is %d, want sunrise", int(getTimeOfDay()))
+	}
+
+	if turn-night >= len(dayCycle)*3 {
+		t.Errorf("slept for %d turns, more than a whole day", turn-night)
+	}
+}
+
+func TestSleepWithMonsters(t *testing.T) {
+	here := newGame(t)
+	put(here, "a bed")
+	room := world.room(here)
+	room.addEntity(newEntity("a zombie"))
+	world.setRoom(here, room)
+	turn = 30
+
+	sleepComm([]string{})
+
+	if turn != 30 {
+		t.Error("slept with a zombie in the room")
+	}
+}
+
+func TestBedSetsSpawn(t *testing.T) {
+	newGame(t)
+	x = 3
+	bedroom := getRoom(x, y, z, false)
+	give("a bed")
+
+	dropComm("bed")
+
+	if spawn != bedroom {
+		t.Errorf("spawn after placing a bed = %v, want %v", spawn, bedroom)
+	}
+
+	commands["take"]([]string{"bed"})
+
+	if spawn != (RoomCoord{}) {
+		t.Errorf("spawn after taking the bed back = %v, want the origin", spawn)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+)
+
+func storeComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		fail("Put what in the chest?")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if _, ok := room.items["a chest"]; !ok {
+		fail("There's no chest here.")
+		return
+	}
+
+	name, ok := findItem(inventory, item)
+
+	if !ok {
+		failf("You don't have a %s.\n", item)
+		return
+	}
+
+	if inventory[name].undroppable {
+		fail("You can't put that in a chest.")
+		return
+	}
+
+	if room.chest == nil {
+		room.chest = make(map[string]Item)
+	}
+
+	room.chest[name] = inventory[name]
+	delete(inventory, name)
+	fmt.Printf("You put %s in the chest.\n", name)
+	world.setRoom(coords, room)
+}
+
+func withdrawComm(item string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if _, ok := room.items["a chest"]; !ok {
+		fail("There's no chest here.")
+		return
+	}
+
+	name, ok := findItem(room.chest, item)
+
+	if !ok {
+		failf("There's no %s in the chest.\n", item)
+		return
+	}
+
+	inventory[name] = room.chest[name]
+	delete(room.chest, name)
+	fmt.Printf("You take %s from the chest.\n", name)
+	world.setRoom(coords, room)
+}
+
+func chestComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if _, ok := room.items["a chest"]; !ok {
+		fail("There's no chest here.")
+		return
+	}
+
+	if isDark(coords) {
+		fail("It's too dark to see inside.")
+		return
+	}
+
+	if len(room.chest) == 0 {
+		fmt.Println("The chest is empty.")
+		return
+	}
+
+	contents := []string{}
+
+	for i := range room.chest {
+		contents = append(contents, i)
+	}
+
+	fmt.Printf("The chest contains %s.\n", itemizeStr(contents))
+}
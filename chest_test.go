@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestChestStoreAndWithdraw(t *testing.T) {
+	here := newGame(t)
+	put(here, "a chest")
+	sword := items["an iron sword"]
+	sword.durability = 12
+	inventory["an iron sword"] = sword
+
+	storeComm([]string{"iron sword"})
+
+	if _, ok := inventory["an iron sword"]; ok {
+		t.Fatal("sword still in the inventory after storing it")
+	}
+
+	if _, ok := world.room(here).chest["an iron sword"]; !ok {
+		t.Fatal("sword isn't in the chest after storing it")
+	}
+
+	withdrawComm("iron sword")
+
+	if got := inventory["an iron sword"].durability; got != 12 {
+		t.Errorf("sword durability after a stay in the chest = %d, want 12", got)
+	}
+
+	if len(world.room(here).chest) != 0 {
+		t.Error("chest isn't empty after taking the sword back")
+	}
+}
+
+func TestChestKeepsOut(t *testing.T) {
+	here := newGame(t)
+	give("some coal")
+
+	storeComm([]string{"coal"})
+
+	if _, ok := inventory["some coal"]; !ok {
+		t.Error("stored coal with no chest in the room")
+	}
+
+	put(here, "a chest")
+	storeComm([]string{"no tea"})
+
+	if _, ok := inventory["no tea"]; !ok {
+		t.Error("stored no tea in a chest")
+	}
+
+	storeComm([]string{"coal"})
+	commands["take"]([]string{"chest"})
+
+	if _, ok := inventory["a chest"]; ok {
+		t.Error("picked up a chest with coal still in it")
+	}
+
+	withdrawComm("coal")
+	commands["take"]([]string{"chest"})
+
+	if _, ok := inventory["a chest"]; !ok {
+		t.Error("couldn't pick up an empty chest")
+	}
+}
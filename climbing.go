@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Passage int
+
+const (
+	Open Passage = iota
+	Steps
+	Ladder
+)
+
+func (e Exits) passage(s string) Passage {
+	return e.kinds[s]
+}
+
+func (e *Exits) setPassage(s string, p Passage) {
+	if e.kinds == nil {
+		e.kinds = make(map[string]Passage)
+	}
+
+	e.kinds[s] = p
+}
+
+func climbable(p Passage) bool {
+	return p == Steps || p == Ladder
+}
+
+// drop takes the player down through the floor. A ladder or steps get them
+// down safely, but an open shaft drops them until they hit the bottom, and
+// falling more than one level hurts. It reports whether they survived.
+func drop(room Room) bool {
+	y -= 1
+
+	if climbable(room.exits.passage("down")) {
+		return true
+	}
+
+	levels := 1
+
+	for y > -3 {
+		below := world.room(getRoom(x, y, z, false))
+
+		if !below.exits.down || climbable(below.exits.passage("down")) {
+			break
+		}
+
+		y -= 1
+		levels += 1
+	}
+
+	if levels == 1 {
+		fmt.Println("You drop down the shaft.")
+		return true
+	}
+
+	damage := (levels - 1) * fallDamage
+	dies := health <= damage
+	fmt.Printf("You fall %d levels and land hard.\n", levels)
+	hurt(damage)
+	return !dies
+}
+
+func describePassages(room Room) {
+	for _, dir := range []string{"up", "down"} {
+		if !room.exits.getExit(dir) {
+			continue
+		}
+
+		switch room.exits.passage(dir) {
+		case Ladder:
+			fmt.Printf("A ladder leads %s.\n", dir)
+		case Steps:
+			fmt.Printf("Rough steps lead %s.\n", dir)
+		}
+	}
+}
+
+func placeLadder(item string) {
+	dir := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(item, "a "), "ladder"))
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if _, ok := inventory["a ladder"]; !ok {
+		fail("You don't have a ladder.")
+		return
+	}
+
+	if dir == "" {
+		if room.exits.up && !climbable(room.exits.passage("up")) {
+			dir = "up"
+		} else {
+			dir = "down"
+		}
+	}
+
+	if dir != "up" && dir != "down" {
+		fail("Ladders only go up or down.")
+		return
+	}
+
+	if !room.exits.getExit(dir) {
+		failf("There's no shaft leading %s here.\n", dir)
+		return
+	}
+
+	if room.exits.passage(dir) == Ladder {
+		failf("There's already a ladder leading %s.\n", dir)
+		return
+	}
+
+	dx, dy, dz := dirOffset(dir)
+	coords1 := getRoom(x+dx, y+dy, z+dz, false)
+	room1 := world.room(coords1)
+	room.exits.setPassage(dir, Ladder)
+	room1.exits.setPassage(opposite(dir), Ladder)
+	world.setRoom(coords, room)
+	world.setRoom(coords1, room1)
+	delete(inventory, "a ladder")
+	fmt.Printf("You fix the ladder to the side of the shaft leading %s.\n", dir)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+)
+
+// shaft opens a straight drop from the surface to the bottom level, with a
+// torch on every level so nobody stumbles about in the dark.
+func shaft() {
+	for h := 0; h > -3; h-- {
+		connect(RoomCoord{y: h}, "down")
+		placeTorch(RoomCoord{y: h - 1}, torchTurns)
+	}
+}
+
+func TestFall(t *testing.T) {
+	newGame(t)
+	shaft()
+
+	walk("down")
+
+	if y != -3 {
+		t.Errorf("fell to y = %d, want the bottom at -3", y)
+	}
+
+	if want := maxHealth - 2*fallDamage; health != want {
+		t.Errorf("health after falling 3 levels = %d, want %d", health, want)
+	}
+}
+
+func TestFallOntoSteps(t *testing.T) {
+	newGame(t)
+	shaft()
+	room := world.room(RoomCoord{y: -1})
+	room.exits.setPassage("down", Steps)
+	world.setRoom(RoomCoord{y: -1}, room)
+
+	walk("down")
+
+	if y != -1 || health != maxHealth {
+		t.Errorf("dropped to y = %d with %d health, want to stop unhurt at the steps on -1", y, health)
+	}
+}
+
+func TestClimb(t *testing.T) {
+	newGame(t)
+	shaft()
+	y = -1
+
+	walk("up")
+
+	if y != -1 {
+		t.Fatal("climbed an open shaft without a ladder")
+	}
+
+	give("a ladder")
+	placeLadder("ladder")
+
+	if _, ok := inventory["a ladder"]; ok {
+		t.Fatal("couldn't fix a ladder in the shaft")
+	}
+
+	if world.room(RoomCoord{}).exits.passage("down") != Ladder {
+		t.Error("the ladder doesn't reach the room above")
+	}
+
+	walk("up")
+
+	if y != 0 {
+		t.Error("couldn't climb the ladder")
+	}
+
+	walk("down")
+
+	if y != -1 || health != maxHealth {
+		t.Errorf("climbed down the ladder to y = %d with %d health, want -1 unhurt", y, health)
+	}
+}
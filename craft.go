@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Station int
+
+const (
+	Hand Station = iota
+	CraftingTable
+	Furnace
+)
+
+type Recipe struct {
+	station     Station
+	ingredients []string
+}
+
+func stationItem(station Station) string {
+	switch station {
+	case CraftingTable:
+		return "a crafting table"
+	case Furnace:
+		return "a furnace"
+	default:
+		return ""
+	}
+}
+
+func hasStation(room Room, station Station) bool {
+	if station == Hand {
+		return true
+	}
+
+	_, ok := room.items[stationItem(station)]
+	return ok
+}
+
+// recipesFor returns the recipes a name could mean, in order. Most names mean
+// a single recipe, but an alias like "pickaxe" is shared by every tier.
+func recipesFor(name string) []string {
+	if _, ok := recipes[name]; ok {
+		return []string{name}
+	}
+
+	found := []string{}
+
+	for n := range recipes {
+		for _, alias := range items[n].aliases {
+			if alias == name {
+				found = append(found, n)
+				break
+			}
+		}
+	}
+
+	sort.Strings(found)
+	return found
+}
+
+func canCraft(room Room, recipe Recipe) bool {
+	if !hasStation(room, recipe.station) {
+		return false
+	}
+
+	for _, req := range recipe.ingredients {
+		if _, ok := inventory[req]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pickRecipe settles which of several recipes the player means. When only
+// one of them can be made here with what they're holding, that's the one;
+// otherwise there's no telling.
+func pickRecipe(room Room, names []string) (string, bool) {
+	if len(names) == 1 {
+		return names[0], true
+	}
+
+	picked := []string{}
+
+	for _, name := range names {
+		if canCraft(room, recipes[name]) {
+			picked = append(picked, name)
+		}
+	}
+
+	if len(picked) != 1 {
+		return "", false
+	}
+
+	return picked[0], true
+}
+
+func itemizeChoices(t []string) string {
+	if len(t) == 1 {
+		return t[0]
+	}
+
+	return strings.Join(t[:len(t)-1], ", ") + " or " + t[len(t)-1]
+}
+
+func craftComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		fail("Craft what?")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+	names := recipesFor(item)
+
+	if len(names) == 0 {
+		failf("You don't know how to make %s.\n", item)
+		return
+	}
+
+	item, ok := pickRecipe(room, names)
+
+	if !ok {
+		failf("Which do you mean: %s?\n", itemizeChoices(names))
+		return
+	}
+
+	recipe := recipes[item]
+
+	if recipe.station == Furnace {
+		failf("You'll need to smelt %s in a furnace.\n", itemizeStr(recipe.ingredients))
+		return
+	}
+
+	if !hasStation(room, recipe.station) {
+		failf("You need a crafting table here to make %s.\n", item)
+		return
+	}
+
+	for _, req := range recipe.ingredients {
+		if _, ok := inventory[req]; !ok {
+			failf("You don't have the items you need to craft %s.\n", item)
+			return
+		}
+	}
+
+	for _, req := range recipe.ingredients {
+		delete(inventory, req)
+	}
+
+	if item == "some torches" {
+		addTorches(items[item].durability)
+		bundleTorch()
+	} else {
+		inventory[item] = items[item]
+	}
+
+	fmt.Println("Crafted.")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCraftAtStation(t *testing.T) {
+	here := newGame(t)
+	give("some planks", "some sticks")
+
+	craftComm([]string{"wooden pickaxe"})
+
+	if _, ok := inventory["a wooden pickaxe"]; ok {
+		t.Fatal("crafted a pickaxe without a crafting table")
+	}
+
+	if _, ok := inventory["some planks"]; !ok {
+		t.Fatal("lost the planks to a failed craft")
+	}
+
+	put(here, "a crafting table")
+	craftComm([]string{"wooden pickaxe"})
+
+	if _, ok := inventory["a wooden pickaxe"]; !ok {
+		t.Error("couldn't craft a pickaxe at a crafting table")
+	}
+
+	for _, used := range []string{"some planks", "some sticks"} {
+		if _, ok := inventory[used]; ok {
+			t.Errorf("%s still in the inventory after crafting", used)
+		}
+	}
+}
+
+func TestCraftByHand(t *testing.T) {
+	newGame(t)
+	give("some wood")
+
+	craftComm([]string{"planks"})
+
+	if _, ok := inventory["some planks"]; !ok {
+		t.Error("couldn't make planks by hand")
+	}
+}
+
+func TestCraftInFurnace(t *testing.T) {
+	here := newGame(t)
+	put(here, "a crafting table", "a furnace")
+	give("some iron")
+
+	craftComm([]string{"iron ingots"})
+
+	if _, ok := inventory["some iron ingots"]; ok {
+		t.Error("crafted ingots instead of smelting them")
+	}
+}
+
+func TestRecipesFor(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"a stone sword", []string{"a stone sword"}},
+		{"planks", []string{"some planks"}},
+		{"torch", []string{"some torches"}},
+		{"shovel", []string{"a diamond shovel", "a stone shovel", "a wooden shovel", "an iron shovel"}},
+		{"unobtainium", []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := recipesFor(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("recipesFor(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// "pickaxe" names every tier of pickaxe, so crafting one makes whichever the
+// player can make, and asks when that's none or more than one of them.
+func TestCraftSharedAlias(t *testing.T) {
+	here := newGame(t)
+	put(here, "a crafting table")
+	give("some sticks")
+
+	craftComm([]string{"pickaxe"})
+
+	if len(inventory) != 2 || actionTurns != 0 {
+		t.Fatalf("crafting a pickaxe with only sticks made something: %v", inventory)
+	}
+
+	give("some stone")
+	actionTurns = 1
+	craftComm([]string{"pickaxe"})
+
+	if _, ok := inventory["a stone pickaxe"]; !ok {
+		t.Fatal("crafting a pickaxe with stone and sticks didn't make a stone pickaxe")
+	}
+
+	give("some planks", "some stone", "some sticks")
+	actionTurns = 1
+	craftComm([]string{"pickaxe"})
+
+	if _, ok := inventory["a wooden pickaxe"]; ok || actionTurns != 0 {
+		t.Error("crafting a pickaxe with planks and stone didn't ask which")
+	}
+
+	if _, ok := inventory["some stone"]; !ok {
+		t.Error("lost the stone to an ambiguous craft")
+	}
+}
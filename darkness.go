@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	holeOdds   = 8
+	ambushOdds = 6
+	fallDamage = 2
+)
+
+// stumble runs after the player moves without being able to see where
+// they're going. They might step into a hole, or walk into something that
+// was waiting in the dark.
+func stumble() {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if y < 0 && y > -3 && rand.Intn(holeOdds) == 0 {
+		room.exits.setExit("down", true)
+		world.setRoom(coords, room)
+
+		y -= 1
+		below := getRoom(x, y, z, false)
+		room1 := world.room(below)
+		room1.exits.setExit("up", true)
+		world.setRoom(below, room1)
+
+		fmt.Println("The floor gives way beneath you, and you fall into the level below.")
+		dies := health <= fallDamage
+		hurt(fallDamage)
+
+		if !dies {
+			lookComm([]string{})
+		}
+	} else if !respawning() && countMonsters(room) < maxMonsters && rand.Intn(ambushOdds) == 0 {
+		monster := randomChoice([]string{"a skeleton", "a zombie", "a spider"})
+		room.addEntity(newEntity(monster))
+		world.setRoom(coords, room)
+
+		fmt.Println("Something lunges at you out of the darkness!")
+		attacked(species[monster].damage)
+	}
+}
+
+func feelComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if !isDark(coords) {
+		fail("You can see well enough without groping around.")
+		return
+	}
+
+	exits := room.getExits()
+
+	if len(exits) == 0 {
+		fmt.Println("You run your hands along the walls, but find no way out.")
+	} else {
+		fmt.Printf("You run your hands along the walls and find openings leading %s.\n", itemizeStr(exits))
+	}
+
+	describePassages(room)
+	describeWalls(room)
+
+	found := []string{}
+
+	for name := range room.items {
+		found = append(found, name)
+	}
+
+	if len(found) > 0 {
+		fmt.Printf("Your fingers brush against %s.\n", itemizeStr(found))
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestStumble(t *testing.T) {
+	newGame(t)
+	falls, ambushes := 0, 0
+
+	for i := 0; i < 300; i++ {
+		x, y, z = i, -1, 0
+		health = maxHealth
+		world.setRoom(RoomCoord{x: x, y: y}, Room{items: map[string]Item{}, valid: true})
+		stumble()
+
+		if y == -2 {
+			falls += 1
+
+			if !world.room(RoomCoord{x: x, y: -1}).exits.getExit("down") || !world.room(RoomCoord{x: x, y: -2}).exits.getExit("up") {
+				t.Fatal("fell through the floor without leaving a hole")
+			}
+		} else if hasMonster(world.room(RoomCoord{x: x, y: -1})) {
+			ambushes += 1
+		}
+	}
+
+	if falls == 0 || ambushes == 0 {
+		t.Errorf("stumbling around in the dark gave %d falls and %d ambushes, want some of each", falls, ambushes)
+	}
+}
+
+func TestStumbleLimits(t *testing.T) {
+	newGame(t)
+	graceUntil = turn + graceTurns
+
+	for i := 0; i < 100; i++ {
+		x, y, z = i, -3, 0
+		health = maxHealth
+		world.setRoom(RoomCoord{x: x, y: y}, Room{items: map[string]Item{}, valid: true})
+		stumble()
+
+		if y != -3 {
+			t.Fatal("fell through the bottom level")
+		}
+
+		if hasMonster(world.room(RoomCoord{x: x, y: y})) {
+			t.Fatal("ambushed while respawning")
+		}
+	}
+}
+
+func TestWalkInTheLight(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	connect(here, "north")
+
+	for i := 0; i < 100; i++ {
+		x, y, z = 0, 0, 0
+		walk("north")
+
+		if health != maxHealth || hasMonster(world.room(RoomCoord{z: 1})) {
+			t.Fatal("stumbled walking in daylight")
+		}
+	}
+}
+
+func TestFeel(t *testing.T) {
+	newGame(t)
+	y = -1
+	cave := RoomCoord{y: -1}
+	world.setRoom(cave, Room{items: map[string]Item{}, valid: true})
+	connect(cave, "north")
+	put(cave, "some coal")
+
+	got := output(t, func() { feelComm([]string{}) })
+	want := "You run your hands along the walls and find openings leading north.\nYour fingers brush against some coal.\n"
+
+	if got != want {
+		t.Errorf("feeling around = %q, want %q", got, want)
+	}
+}
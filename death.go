@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TwiN/go-color"
+)
+
+const (
+	despawnTurns = 60
+	graceTurns   = 5
+)
+
+var (
+	hardcore = false
+	// graceUntil is the turn up to which monsters leave a freshly respawned
+	// player alone, so they aren't killed again before they can get away.
+	graceUntil = 0
+)
+
+func hurt(damage int) {
+	health -= damage
+
+	if health <= 0 {
+		die()
+	}
+}
+
+func respawning() bool {
+	return turn < graceUntil
+}
+
+func graveActive(room Room) bool {
+	return len(room.grave) > 0 && turn < room.graveExpires
+}
+
+func die() {
+	fmt.Println(color.Ize(color.Red, "You have died."))
+
+	if hardcore {
+		if err := world.delete(); err != nil {
+			fmt.Println(color.Ize(color.Red, fmt.Sprintf("Couldn't delete the world: %v", err)))
+		}
+
+		running = false
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if !graveActive(room) {
+		room.grave = make(map[string]Item)
+	}
+
+	for name, item := range inventory {
+		if !item.undroppable {
+			room.grave[name] = item
+			delete(inventory, name)
+		}
+	}
+
+	for name, item := range equipment {
+		room.grave[name] = item
+		delete(equipment, name)
+	}
+
+	room.graveExpires = turn + despawnTurns
+	world.setRoom(coords, room)
+
+	x = spawn.x
+	y = spawn.y
+	z = spawn.z
+	health = maxHealth
+	timeInRoom = 0
+	graceUntil = turn + graceTurns
+
+	home := getRoom(x, y, z, false)
+	homeRoom := world.room(home)
+	kept := []Entity{}
+
+	for _, e := range homeRoom.entities {
+		if !e.hostile {
+			kept = append(kept, e)
+		}
+	}
+
+	homeRoom.entities = kept
+	world.setRoom(home, homeRoom)
+
+	if _, ok := homeRoom.items["a bed"]; ok {
+		fmt.Println("You wake up in your bed.")
+	} else {
+		fmt.Println("You wake up back where you started.")
+	}
+
+	world.unloadDistant(RoomCoord{x: x, y: y, z: z})
+	lookComm([]string{})
+}
+
+func recoverComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if !graveActive(room) {
+		fail("There's nothing of yours here.")
+		return
+	}
+
+	names := []string{}
+
+	for name, item := range room.grave {
+		inventory[name] = item
+		names = append(names, name)
+	}
+
+	room.grave = nil
+	room.graveExpires = 0
+	fmt.Printf("You gather up %s.\n", itemizeStr(names))
+	world.setRoom(coords, room)
+}
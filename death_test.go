@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDieAndRecover(t *testing.T) {
+	newGame(t)
+	x = 5
+	deathplace := getRoom(x, y, z, false)
+	give("some coal", "a stone sword")
+	health = 2
+
+	hurt(3)
+
+	if x != 0 || y != 0 || z != 0 {
+		t.Errorf("respawned at %d, %d, %d, want the origin", x, y, z)
+	}
+
+	if health != maxHealth {
+		t.Errorf("health after respawning = %d, want %d", health, maxHealth)
+	}
+
+	if len(inventory) != 1 {
+		t.Errorf("inventory after dying = %v, want only no tea", inventory)
+	}
+
+	if _, ok := world.room(deathplace).grave["a stone sword"]; !ok {
+		t.Fatal("the sword wasn't left where the player died")
+	}
+
+	x = 5
+	recoverComm([]string{})
+
+	if _, ok := inventory["a stone sword"]; !ok {
+		t.Error("couldn't recover the sword")
+	}
+
+	if _, ok := inventory["some coal"]; !ok {
+		t.Error("couldn't recover the coal")
+	}
+}
+
+func TestGraveDespawns(t *testing.T) {
+	newGame(t)
+	give("some coal")
+	hurt(maxHealth)
+
+	turn += despawnTurns
+	recoverComm([]string{})
+
+	if _, ok := inventory["some coal"]; ok {
+		t.Error("recovered coal from a grave that should have despawned")
+	}
+}
+
+func TestRespawnGrace(t *testing.T) {
+	home := newGame(t)
+	room := world.room(home)
+	room.addEntity(newEntity("a zombie"))
+	room.addEntity(newEntity("a pig"))
+	world.setRoom(home, room)
+	x = 5
+	getRoom(x, y, z, false)
+
+	hurt(maxHealth)
+
+	if hasMonster(world.room(home)) {
+		t.Error("the zombie was still waiting at the spawn point")
+	}
+
+	if len(world.room(home).entities) != 1 {
+		t.Error("the pig at the spawn point was cleared along with the zombie")
+	}
+
+	if !respawning() {
+		t.Error("no grace after respawning")
+	}
+
+	turn += graceTurns
+
+	if respawning() {
+		t.Errorf("still in grace %d turns after respawning", graceTurns)
+	}
+}
+
+func TestHardcore(t *testing.T) {
+	newGame(t)
+	hardcore = true
+	world.save()
+
+	hurt(maxHealth)
+
+	if running {
+		t.Error("the game carried on after a hardcore death")
+	}
+
+	if !world.deleted {
+		t.Error("the world wasn't deleted after a hardcore death")
+	}
+}
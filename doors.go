@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+)
+
+var (
+	doorItems = []string{
+		"a door", "a gate",
+	}
+)
+
+func (e Exits) door(s string) (string, bool) {
+	name, ok := e.doors[s]
+	return name, ok
+}
+
+func (e *Exits) setDoor(s string, name string) {
+	if e.doors == nil {
+		e.doors = make(map[string]string)
+	}
+
+	e.doors[s] = name
+}
+
+func isDoor(name string) bool {
+	for _, door := range doorItems {
+		if door == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A room is a shelter when there's no open way in and enough light to see
+// by. That's less light than it takes to keep monsters away in the open, so
+// a sealed hut lit only by the moon is still safe.
+func isShelter(coords RoomCoord) bool {
+	room := world.room(coords)
+	return len(room.getExits()) == 0 && lightLevel(coords) >= seeLight
+}
+
+func placeDoor(name string, dir string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if dir == "up" || dir == "down" {
+		failf("You can't hang %s in a shaft.\n", name)
+		return
+	}
+
+	if _, ok := room.exits.door(dir); ok {
+		failf("There's already a door to the %s.\n", dir)
+		return
+	}
+
+	if !room.exits.getExit(dir) {
+		failf("There's no way through to the %s to put %s in.\n", dir, name)
+		return
+	}
+
+	coords1 := neighbour(dir)
+	room1 := world.room(coords1)
+	room.exits.setDoor(dir, name)
+	room1.exits.setDoor(opposite(dir), name)
+	world.setRoom(coords, room)
+	world.setRoom(coords1, room1)
+	delete(inventory, name)
+	fmt.Printf("You hang %s in the way %s. It's open.\n", name, dir)
+}
+
+// findDoor works out which door the player means. Without a direction, it
+// only guesses when there's just one door in the room.
+func findDoor(room Room, dir string) (string, bool) {
+	if dir != "" {
+		_, ok := room.exits.door(dir)
+		return dir, ok
+	}
+
+	found := ""
+
+	for _, d := range directions {
+		if _, ok := room.exits.door(d); ok {
+			if found != "" {
+				return "", false
+			}
+
+			found = d
+		}
+	}
+
+	return found, found != ""
+}
+
+func setDoorOpen(vals []string, open bool) {
+	var dir string
+
+	if len(vals) == 0 {
+		dir = ""
+	} else {
+		dir = vals[0]
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+	found, ok := findDoor(room, dir)
+
+	if !ok {
+		if dir != "" {
+			failf("There's no door to the %s.\n", dir)
+		} else if len(room.exits.doors) == 0 {
+			fail("There's no door here.")
+		} else {
+			fail("Which door?")
+		}
+
+		return
+	}
+
+	dir = found
+
+	name, _ := room.exits.door(dir)
+
+	if room.exits.getExit(dir) == open {
+		if open {
+			failf("The %s is already open.\n", items[name].aliases[0])
+		} else {
+			failf("The %s is already closed.\n", items[name].aliases[0])
+		}
+
+		return
+	}
+
+	coords1 := neighbour(dir)
+	room1 := world.room(coords1)
+	room.exits.setExit(dir, open)
+	room1.exits.setExit(opposite(dir), open)
+	world.setRoom(coords, room)
+	world.setRoom(coords1, room1)
+
+	if open {
+		fmt.Printf("You open the %s to the %s.\n", items[name].aliases[0], dir)
+	} else {
+		fmt.Printf("You close the %s to the %s.\n", items[name].aliases[0], dir)
+	}
+}
+
+func openComm(vals []string) {
+	setDoorOpen(vals, true)
+}
+
+func closeComm(vals []string) {
+	setDoorOpen(vals, false)
+}
+
+func describeDoors(room Room) {
+	for _, dir := range directions {
+		name, ok := room.exits.door(dir)
+
+		if !ok {
+			continue
+		}
+
+		if room.exits.getExit(dir) {
+			fmt.Printf("The %s to the %s is open.\n", items[name].aliases[0], dir)
+		} else {
+			fmt.Printf("The %s to the %s is closed.\n", items[name].aliases[0], dir)
+		}
+	}
+}
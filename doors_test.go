@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestShelter(t *testing.T) {
+	world = newWorld(t.TempDir())
+	turn = 0
+
+	for !timesOfDay["night"](int(getTimeOfDay())) {
+		turn += 1
+	}
+
+	hut := RoomCoord{x: 0, y: 0, z: 0}
+	cave := RoomCoord{x: 0, y: -1, z: 0}
+	world.setRoom(hut, Room{items: map[string]Item{}, valid: true})
+	world.setRoom(cave, Room{items: map[string]Item{}, valid: true})
+
+	if light := lightLevel(hut); light >= spawnLight {
+		t.Fatalf("moonlit hut has light %d, want less than %d", light, spawnLight)
+	}
+
+	if !isShelter(hut) {
+		t.Error("sealed moonlit hut isn't a shelter")
+	}
+
+	if isShelter(cave) {
+		t.Error("sealed pitch dark cave is a shelter")
+	}
+
+	x, y, z = 0, 0, 0
+
+	for i := 0; i < 200; i++ {
+		spawnMonsters()
+	}
+
+	if n := countMonsters(world.room(hut)); n != 0 {
+		t.Errorf("%d monsters spawned in the sealed hut", n)
+	}
+
+	room := world.room(hut)
+	room.exits.setExit("north", true)
+	world.setRoom(hut, room)
+
+	if isShelter(hut) {
+		t.Error("hut with an open exit is a shelter")
+	}
+}
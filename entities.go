@@ -0,0 +1,469 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+const (
+	growUpTurns = 30
+	loveTurns   = 6
+	breedTurns  = 20
+	tameChance  = 3
+	maxHerdSize = 3
+	wolfOdds    = 4
+	maxMonsters = 2
+)
+
+type Species struct {
+	aliases   []string
+	plural    string
+	desc      string
+	drops     []string
+	hitDrops  []string
+	health    int
+	damage    int
+	monster   bool
+	nocturnal bool
+	provoked  bool
+	breedFood string
+	tameFood  string
+	verb      string
+}
+
+var (
+	species = map[string]Species{
+		"a pig": {
+			aliases:   []string{"pig"},
+			plural:    "pigs",
+			drops:     []string{"some pork"},
+			health:    4,
+			breedFood: "some wheat",
+			desc:      "The pig has a square nose.",
+		},
+		"a cow": {
+			aliases:   []string{"cow"},
+			plural:    "cows",
+			drops:     []string{"some leather"},
+			health:    5,
+			breedFood: "some wheat",
+			desc:      "The cow stares at you blankly.",
+		},
+		"a sheep": {
+			aliases:   []string{"sheep"},
+			plural:    "sheep",
+			hitDrops:  []string{"some wool"},
+			health:    4,
+			breedFood: "some wheat",
+			desc:      "The sheep is fluffy.",
+		},
+		"a chicken": {
+			aliases:   []string{"chicken"},
+			plural:    "chickens",
+			drops:     []string{"some chicken", "some feathers"},
+			health:    2,
+			breedFood: "some seeds",
+			verb:      "struts",
+			desc:      "The chicken looks delicious.",
+		},
+		"a wolf": {
+			aliases:   []string{"wolf", "dog"},
+			plural:    "wolves",
+			health:    5,
+			damage:    2,
+			provoked:  true,
+			breedFood: "some pork",
+			tameFood:  "some bones",
+			verb:      "pads",
+			desc:      "The wolf eyes you warily. Maybe it would like a bone.",
+		},
+		"a creeper": {
+			aliases: []string{"creeper"},
+			plural:  "creepers",
+			health:  4,
+			damage:  7,
+			monster: true,
+			verb:    "creeps",
+			desc:    "The creeper needs a hug.",
+		},
+		"a skeleton": {
+			aliases:   []string{"skeleton"},
+			plural:    "skeletons",
+			drops:     []string{"some bones", "some arrows"},
+			health:    5,
+			damage:    3,
+			monster:   true,
+			nocturnal: true,
+			verb:      "rattles",
+			desc:      "The head bone's connected to the neck bone, the neck bone's connected to the chest bone, the chest bone's connected to the arm bone, the arm bone's connected to the bow, and the bow is pointed at you.",
+		},
+		"a zombie": {
+			aliases:   []string{"zombie"},
+			plural:    "zombies",
+			health:    6,
+			damage:    4,
+			monster:   true,
+			nocturnal: true,
+			verb:      "shambles",
+			desc:      "All he wants to do is eat your brains.",
+		},
+		"a spider": {
+			aliases: []string{"spider"},
+			plural:  "spiders",
+			drops:   []string{"some string"},
+			health:  5,
+			damage:  3,
+			monster: true,
+			verb:    "scuttles",
+			desc:    "Dozens of eyes stare back at you.",
+		},
+	}
+	animals = []string{
+		"a pig", "a cow", "a sheep", "a chicken",
+	}
+	monsters = []string{
+		"a creeper", "a skeleton", "a zombie", "a spider",
+	}
+)
+
+// Entity is a single creature in the world. What it does is decided by its
+// species; everything that differs from one pig to the next lives here.
+type Entity struct {
+	species  string
+	health   int
+	hostile  bool
+	born     int
+	love     int
+	cooldown int
+	fuse     int
+}
+
+var pets = []Entity{}
+
+func newEntity(name string) Entity {
+	kind := species[name]
+
+	// Entities generated with the world start out grown up.
+	return Entity{
+		species: name,
+		health:  kind.health,
+		hostile: kind.monster,
+		born:    turn - growUpTurns,
+	}
+}
+
+func (e Entity) kind() Species {
+	return species[e.species]
+}
+
+func (e Entity) baby() bool {
+	return turn-e.born < growUpTurns
+}
+
+func (e Entity) inLove() bool {
+	return turn < e.love
+}
+
+func (e Entity) alias() string {
+	return e.kind().aliases[0]
+}
+
+func (e Entity) name() string {
+	if e.baby() {
+		return "a baby " + e.alias()
+	}
+
+	return e.species
+}
+
+func (r *Room) addEntity(e Entity) {
+	r.entities = append(r.entities, e)
+}
+
+func (r *Room) removeEntity(i int) Entity {
+	e := r.entities[i]
+	r.entities = append(r.entities[:i], r.entities[i+1:]...)
+	return e
+}
+
+func findEntity(room Room, name string) (int, bool) {
+	found := -1
+
+	for i, e := range room.entities {
+		if e.species != name && e.name() != name && !hasAlias(e.kind().aliases, name) {
+			continue
+		}
+
+		if !e.baby() {
+			return i, true
+		}
+
+		if found == -1 {
+			found = i
+		}
+	}
+
+	return found, found != -1
+}
+
+func hasAlias(aliases []string, name string) bool {
+	for _, alias := range aliases {
+		if alias == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasMonster(room Room) bool {
+	return countMonsters(room) > 0
+}
+
+func countMonsters(room Room) int {
+	n := 0
+
+	for _, e := range room.entities {
+		if e.hostile {
+			n += 1
+		}
+	}
+
+	return n
+}
+
+func countWord(n int) string {
+	words := []string{"no", "one", "two", "three", "four", "five", "six"}
+
+	if n < len(words) {
+		return words[n]
+	}
+
+	return strconv.Itoa(n)
+}
+
+func describeEntities(entities []Entity) []string {
+	type group struct {
+		species string
+		baby    bool
+	}
+
+	counts := map[group]int{}
+	order := []group{}
+
+	for _, e := range entities {
+		g := group{species: e.species, baby: e.baby()}
+
+		if counts[g] == 0 {
+			order = append(order, g)
+		}
+
+		counts[g] += 1
+	}
+
+	names := []string{}
+
+	for _, g := range order {
+		plural := species[g.species].plural
+
+		if g.baby {
+			plural = "baby " + plural
+		}
+
+		if counts[g] == 1 && g.baby {
+			names = append(names, "a baby "+species[g.species].aliases[0])
+		} else if counts[g] == 1 {
+			names = append(names, g.species)
+		} else {
+			names = append(names, countWord(counts[g])+" "+plural)
+		}
+	}
+
+	return names
+}
+
+func attackDamage(iTool Item, fTool bool) int {
+	toolLevel := 0
+
+	if fTool && iTool.toolType == Sword {
+		toolLevel = iTool.toolLevel
+	}
+
+	damage := []int{1, 2, 3, 5, 8}
+	return damage[toolLevel]
+}
+
+func dropLoot(room *Room, e Entity, drops []string) {
+	for _, drop := range drops {
+		if _, ok := room.items[drop]; !ok {
+			fmt.Printf("The %s dropped %s.\n", e.alias(), drop)
+			room.items[drop] = items[drop]
+		}
+	}
+}
+
+func attackEntity(room *Room, i int, iTool Item, fTool bool) {
+	e := &room.entities[i]
+	kind := e.kind()
+	e.health -= attackDamage(iTool, fTool)
+
+	if !e.baby() {
+		dropLoot(room, *e, kind.hitDrops)
+	}
+
+	if e.health <= 0 {
+		dead := room.removeEntity(i)
+		fmt.Printf("The %s dies.\n", dead.alias())
+
+		if !dead.baby() {
+			dropLoot(room, dead, kind.drops)
+		}
+
+		return
+	}
+
+	fmt.Printf("The %s is injured by your blow.\n", e.alias())
+
+	if kind.provoked && !e.hostile {
+		e.hostile = true
+		fmt.Printf("The %s growls at you.\n", e.alias())
+	}
+}
+
+func feedComm(vals []string) {
+	var target string
+	var food string
+
+	if len(vals) == 0 {
+		target = ""
+		food = ""
+	} else if len(vals) == 1 {
+		target = vals[0]
+		food = ""
+	} else {
+		target = vals[0]
+		food = vals[1]
+	}
+
+	if target == "" {
+		fail("Feed what?")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+	i, ok := findEntity(room, target)
+
+	if !ok {
+		failf("You don't see any %s here.\n", target)
+		return
+	}
+
+	if room.entities[i].inLove() {
+		// Feed one that isn't already looking for a mate.
+		for j, other := range room.entities {
+			if other.species == room.entities[i].species && !other.baby() && !other.inLove() {
+				i = j
+				break
+			}
+		}
+	}
+
+	e := room.entities[i]
+	kind := e.kind()
+
+	if food == "" {
+		food = kind.breedFood
+
+		if kind.tameFood != "" {
+			food = kind.tameFood
+		}
+	}
+
+	name, ok := findItem(inventory, food)
+
+	if !ok {
+		failf("You don't have any %s.\n", food)
+		return
+	}
+
+	if name == kind.tameFood {
+		delete(inventory, name)
+
+		if e.hostile || rand.Intn(tameChance) != 0 {
+			fmt.Printf("The %s eats %s, but keeps its distance.\n", e.alias(), name)
+		} else {
+			pets = append(pets, room.removeEntity(i))
+			fmt.Printf("The %s eats %s and wags its tail. It will follow you now.\n", e.alias(), name)
+		}
+
+		world.setRoom(coords, room)
+		return
+	}
+
+	if name != kind.breedFood {
+		failf("The %s doesn't want %s.\n", e.alias(), name)
+		return
+	}
+
+	if e.baby() {
+		delete(inventory, name)
+		room.entities[i].born -= growUpTurns / 3
+		fmt.Printf("The baby %s eats %s and grows a little.\n", e.alias(), name)
+		world.setRoom(coords, room)
+		return
+	}
+
+	if turn < e.cooldown {
+		failf("The %s isn't hungry right now.\n", e.alias())
+		return
+	}
+
+	delete(inventory, name)
+	room.entities[i].love = turn + loveTurns
+
+	for j, mate := range room.entities {
+		if j == i || mate.species != e.species || mate.baby() || !mate.inLove() {
+			continue
+		}
+
+		room.entities[i].love = 0
+		room.entities[i].cooldown = turn + breedTurns
+		room.entities[j].love = 0
+		room.entities[j].cooldown = turn + breedTurns
+		room.addEntity(Entity{species: e.species, health: kind.health, born: turn})
+		fmt.Printf("The %s eats %s. Hearts appear, and a baby %s is born!\n", e.alias(), name, e.alias())
+		world.setRoom(coords, room)
+		return
+	}
+
+	fmt.Printf("The %s eats %s and looks for a mate.\n", e.alias(), name)
+	world.setRoom(coords, room)
+}
+
+func petsFight() {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	for p := range pets {
+		for i, e := range room.entities {
+			if !e.hostile {
+				continue
+			}
+
+			room.entities[i].health -= pets[p].kind().damage
+
+			if room.entities[i].health <= 0 {
+				room.removeEntity(i)
+				fmt.Printf("Your %s kills the %s.\n", pets[p].alias(), e.alias())
+			} else {
+				fmt.Printf("Your %s bites the %s.\n", pets[p].alias(), e.alias())
+			}
+
+			break
+		}
+	}
+
+	world.setRoom(coords, room)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLootTables(t *testing.T) {
+	for name, kind := range species {
+		for _, drop := range append(kind.drops, kind.hitDrops...) {
+			if _, ok := items[drop]; !ok {
+				t.Errorf("%s drops %q, which isn't an item", name, drop)
+			}
+		}
+
+		for _, food := range []string{kind.breedFood, kind.tameFood} {
+			if _, ok := items[food]; food != "" && !ok {
+				t.Errorf("%s eats %q, which isn't an item", name, food)
+			}
+		}
+	}
+}
+
+func TestBreed(t *testing.T) {
+	here := newGame(t)
+	room := world.room(here)
+	room.addEntity(newEntity("a pig"))
+	room.addEntity(newEntity("a pig"))
+	world.setRoom(here, room)
+
+	give("some wheat")
+	feedComm([]string{"pig"})
+	give("some wheat")
+	feedComm([]string{"pig"})
+
+	entities := world.room(here).entities
+
+	if len(entities) != 3 || !entities[2].baby() {
+		t.Fatalf("pigs after feeding both = %v, want two pigs and a baby", describeEntities(entities))
+	}
+
+	give("some wheat")
+	actionTurns = 1
+	feedComm([]string{"a pig"})
+
+	if _, ok := inventory["some wheat"]; !ok || actionTurns != 0 {
+		t.Error("a pig that just bred ate more wheat")
+	}
+
+	born := world.room(here).entities[2].born
+	feedComm([]string{"a baby pig"})
+
+	if got := world.room(here).entities[2].born; got != born-growUpTurns/3 {
+		t.Errorf("feeding the baby moved its birth from %d to %d, want %d", born, got, born-growUpTurns/3)
+	}
+
+	turn += growUpTurns
+
+	if world.room(here).entities[2].baby() {
+		t.Error("the baby pig never grew up")
+	}
+}
+
+func TestTame(t *testing.T) {
+	here := newGame(t)
+	room := world.room(here)
+	room.addEntity(newEntity("a wolf"))
+	world.setRoom(here, room)
+
+	for i := 0; i < 100 && len(pets) == 0; i++ {
+		give("some bones")
+		feedComm([]string{"wolf"})
+	}
+
+	if len(pets) != 1 || pets[0].species != "a wolf" {
+		t.Fatalf("pets after feeding a wolf bones = %v, want the wolf", pets)
+	}
+
+	if len(world.room(here).entities) != 0 {
+		t.Error("the tamed wolf is still in the room as well as following the player")
+	}
+
+	room = world.room(here)
+	room.addEntity(newEntity("a zombie"))
+	world.setRoom(here, room)
+
+	for i := 0; i < 10 && hasMonster(world.room(here)); i++ {
+		petsFight()
+	}
+
+	if hasMonster(world.room(here)) {
+		t.Error("the pet wolf didn't fight off the zombie")
+	}
+}
+
+func TestDescribeEntities(t *testing.T) {
+	newGame(t)
+	baby := newEntity("a pig")
+	baby.born = turn
+
+	entities := []Entity{newEntity("a pig"), newEntity("a cow"), newEntity("a pig"), baby, newEntity("a zombie")}
+	want := []string{"two pigs", "a cow", "a baby pig", "a zombie"}
+
+	if got := describeEntities(entities); !reflect.DeepEqual(got, want) {
+		t.Errorf("describeEntities() = %q, want %q", got, want)
+	}
+}
+
+func TestFindEntity(t *testing.T) {
+	newGame(t)
+	baby := newEntity("a pig")
+	baby.born = turn
+	room := Room{}
+	room.addEntity(baby)
+	room.addEntity(newEntity("a pig"))
+
+	if i, ok := findEntity(room, "pig"); !ok || i != 1 {
+		t.Errorf("findEntity(pig) = %d, %v, want the grown pig at 1", i, ok)
+	}
+
+	if i, ok := findEntity(room, "a baby pig"); !ok || i != 0 {
+		t.Errorf("findEntity(a baby pig) = %d, %v, want the baby at 0", i, ok)
+	}
+
+	if _, ok := findEntity(room, "cow"); ok {
+		t.Error("found a cow in a room of pigs")
+	}
+}
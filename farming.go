@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const cropGrowth = 24
+
+type Crop struct {
+	planted bool
+	growth  int
+	updated int
+}
+
+func (c Crop) ripe() bool {
+	return c.planted && c.growth >= cropGrowth
+}
+
+// Crops grow twice as fast in daylight. Growth is caught up lazily so that
+// crops in rooms the player isn't in, or in unloaded chunks, still grow.
+func growCrop(room *Room) {
+	for t := room.crop.updated; t < turn && room.crop.growth < cropGrowth; t++ {
+		if isSunnyAt(t) {
+			room.crop.growth += 2
+		} else {
+			room.crop.growth += 1
+		}
+	}
+
+	room.crop.updated = turn
+}
+
+func breakGrass(room Room) {
+	if y != 0 || !hasGrass(room.biome) {
+		fail("There's no grass here.")
+		return
+	}
+
+	if rand.Intn(3) == 0 {
+		fmt.Println("You pull up some grass and find some seeds, which you pick up.")
+		inventory["some seeds"] = items["some seeds"]
+	} else {
+		fmt.Println("You pull up some grass, but there's nothing in it.")
+	}
+}
+
+func plantComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		fail("Plant what?")
+		return
+	}
+
+	if name, ok := findItem(inventory, item); !ok || name != "some seeds" {
+		fail("You don't have any seeds.")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if y != 0 || !hasGrass(room.biome) {
+		fail("Nothing will grow here.")
+		return
+	}
+
+	if room.crop.planted {
+		fail("Something is already growing here.")
+		return
+	}
+
+	shovel, ok := bestTool(Shovel, 1)
+
+	if !ok {
+		fail("You need a shovel to till the ground.")
+		return
+	}
+
+	delete(inventory, "some seeds")
+	room.crop = Crop{planted: true, updated: turn}
+	fmt.Printf("You till the ground with %s and plant the seeds.\n", shovel)
+	world.setRoom(coords, room)
+}
+
+func harvestComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if !room.crop.planted {
+		fail("There's nothing to harvest here.")
+		return
+	}
+
+	growCrop(&room)
+
+	if !room.crop.ripe() {
+		fail("The wheat isn't ready yet.")
+		world.setRoom(coords, room)
+		return
+	}
+
+	room.crop = Crop{}
+	inventory["some wheat"] = items["some wheat"]
+	inventory["some seeds"] = items["some seeds"]
+	fmt.Println("You harvest the wheat, and collect some wheat and some seeds.")
+	world.setRoom(coords, room)
+}
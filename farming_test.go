@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestGrowCrop(t *testing.T) {
+	newGame(t)
+
+	day := Room{crop: Crop{planted: true}}
+	turn = 5
+	growCrop(&day)
+
+	if day.crop.growth != 10 {
+		t.Errorf("growth after 5 daylight turns = %d, want 10", day.crop.growth)
+	}
+
+	night := Room{crop: Crop{planted: true, updated: 30}}
+	turn = 35
+	growCrop(&night)
+
+	if night.crop.growth != 5 {
+		t.Errorf("growth after 5 night turns = %d, want 5", night.crop.growth)
+	}
+
+	turn = 1000
+	growCrop(&day)
+
+	if day.crop.growth != cropGrowth || !day.crop.ripe() {
+		t.Errorf("growth after a long wait = %d, want %d", day.crop.growth, cropGrowth)
+	}
+}
+
+func TestPlantAndHarvest(t *testing.T) {
+	here := newGame(t)
+	room := world.room(here)
+	room.biome = 4
+	world.setRoom(here, room)
+	give("some seeds", "a wooden shovel")
+
+	plantComm([]string{"seeds"})
+
+	if world.room(here).crop.planted {
+		t.Fatal("planted seeds in the desert")
+	}
+
+	room.biome = 5
+	world.setRoom(here, room)
+	delete(inventory, "a wooden shovel")
+	plantComm([]string{"seeds"})
+
+	if world.room(here).crop.planted {
+		t.Fatal("planted seeds without a shovel")
+	}
+
+	give("a wooden shovel")
+	plantComm([]string{"seeds"})
+
+	if !world.room(here).crop.planted {
+		t.Fatal("couldn't plant seeds in a plain with a shovel")
+	}
+
+	harvestComm([]string{})
+
+	if _, ok := inventory["some wheat"]; ok {
+		t.Fatal("harvested wheat straight after planting it")
+	}
+
+	// The crop keeps growing while its chunk is unloaded.
+	x = 40
+	getRoom(x, y, z, false)
+	world.unloadDistant(RoomCoord{x: x})
+	turn += cropGrowth
+	x = 0
+
+	harvestComm([]string{})
+
+	if _, ok := inventory["some wheat"]; !ok {
+		t.Error("couldn't harvest the wheat once it was ripe")
+	}
+
+	if _, ok := inventory["some seeds"]; !ok {
+		t.Error("didn't get seeds back from the harvest")
+	}
+
+	if world.room(here).crop.planted {
+		t.Error("the crop is still planted after the harvest")
+	}
+}
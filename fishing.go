@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const fishTurns = 4
+
+type Catch struct {
+	item   string
+	weight int
+	fish   bool
+}
+
+var (
+	catches = []Catch{
+		{item: "some fish", weight: 50, fish: true},
+		{item: "some salmon", weight: 20, fish: true},
+		{item: "an old boot", weight: 15},
+		{item: "some sticks", weight: 10},
+		{item: "some string", weight: 5},
+	}
+)
+
+// Fish bite best while the light is changing, so at dawn and dusk the
+// fish in the loot table count for twice as much.
+func catchWeight(c Catch) int {
+	tod := int(getTimeOfDay())
+
+	if c.fish && (tod == 9 || tod == len(dayCycle)) {
+		return c.weight * 2
+	}
+
+	return c.weight
+}
+
+func randomCatch() string {
+	total := 0
+
+	for _, c := range catches {
+		total += catchWeight(c)
+	}
+
+	n := rand.Intn(total)
+
+	for _, c := range catches {
+		n -= catchWeight(c)
+
+		if n < 0 {
+			return c.item
+		}
+	}
+
+	return catches[0].item
+}
+
+func fishComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if _, ok := room.items["a river"]; !ok {
+		fail("There's no water to fish in here.")
+		return
+	}
+
+	if _, ok := inventory["a fishing rod"]; !ok {
+		fail("You need a fishing rod.")
+		return
+	}
+
+	catch := randomCatch()
+	inventory[catch] = items[catch]
+	actionTurns = fishTurns
+	fmt.Printf("You cast your line into the river and wait. Eventually you reel in %s.\n", catch)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCatches(t *testing.T) {
+	for _, c := range catches {
+		if _, ok := items[c.item]; !ok {
+			t.Errorf("catch %q isn't an item", c.item)
+		}
+
+		if c.weight <= 0 {
+			t.Errorf("catch %q has weight %d", c.item, c.weight)
+		}
+
+		if c.fish && !items[c.item].food {
+			t.Errorf("fish %q isn't food", c.item)
+		}
+	}
+}
+
+func TestCatchWeight(t *testing.T) {
+	tests := []struct {
+		tod   int
+		catch Catch
+		want  int
+	}{
+		{1, Catch{weight: 10, fish: true}, 10},
+		{9, Catch{weight: 10, fish: true}, 20},
+		{12, Catch{weight: 10, fish: true}, 10},
+		{15, Catch{weight: 10, fish: true}, 20},
+		{15, Catch{weight: 10}, 10},
+	}
+
+	for _, tt := range tests {
+		turn = (tt.tod - 1) * 3
+
+		if got := catchWeight(tt.catch); got != tt.want {
+			t.Errorf("catchWeight(%+v) at %d = %d, want %d", tt.catch, tt.tod, got, tt.want)
+		}
+	}
+}
+
+func TestRandomCatch(t *testing.T) {
+	turn = 0
+
+	for i := 0; i < 100; i++ {
+		found := false
+		catch := randomCatch()
+
+		for _, c := range catches {
+			if c.item == catch {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Fatalf("randomCatch() = %q, which isn't in the catches", catch)
+		}
+	}
+}
+
+func TestFish(t *testing.T) {
+	here := riverRoom(t)
+	room := world.room(here)
+	delete(room.items, "a river")
+	world.setRoom(here, room)
+	give("a fishing rod")
+
+	fishComm([]string{})
+
+	if len(inventory) != 2 || actionTurns != 0 {
+		t.Fatal("caught something without a river")
+	}
+
+	put(here, "a river")
+	delete(inventory, "a fishing rod")
+	fishComm([]string{})
+
+	if len(inventory) != 1 || actionTurns != 0 {
+		t.Fatal("caught something without a rod")
+	}
+
+	give("a fishing rod")
+	fishComm([]string{})
+
+	if len(inventory) != 3 {
+		t.Errorf("inventory after fishing = %v, want a catch", inventory)
+	}
+
+	if actionTurns != fishTurns {
+		t.Errorf("fishing took %d turns, want %d", actionTurns, fishTurns)
+	}
+}
+
+// Cooked fish is worth more than raw fish.
+func TestCookFish(t *testing.T) {
+	here := newGame(t)
+	put(here, "a furnace")
+	give("some fish", "some coal")
+
+	smeltComm([]string{"fish"})
+
+	if got := world.room(here).furnace.output; got != "some cooked fish" {
+		t.Fatalf("furnace output = %q, want some cooked fish", got)
+	}
+
+	if items["some cooked fish"].heal <= items["some fish"].heal {
+		t.Error("cooking the fish doesn't make it any more filling")
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+)
+
+const smeltTurns = 3
+
+type Smelt struct {
+	output string
+	done   int
+}
+
+var (
+	fuels = []string{
+		"some coal", "some wood", "some planks", "some sticks",
+	}
+)
+
+func smeltComm(vals []string) {
+	var item string
+	var fuel string
+
+	if len(vals) == 0 {
+		item = ""
+		fuel = ""
+	} else if len(vals) == 1 {
+		item = vals[0]
+		fuel = ""
+	} else {
+		item = vals[0]
+		fuel = vals[1]
+	}
+
+	if item == "" {
+		fail("Smelt what?")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	finishSmelt(coords)
+	room := world.room(coords)
+
+	if !hasStation(room, Furnace) {
+		fail("You need to place a furnace here first.")
+		return
+	}
+
+	if room.furnace.output != "" {
+		fail("The furnace is already burning.")
+		return
+	}
+
+	name, ok := findItem(inventory, item)
+
+	if !ok {
+		failf("You don't have any %s.\n", item)
+		return
+	}
+
+	output, ok := smeltOutput(name)
+
+	if !ok {
+		failf("You can't smelt %s.\n", name)
+		return
+	}
+
+	if fuel != "" {
+		fuel, ok = findItem(inventory, fuel)
+
+		if !ok {
+			failf("You don't have any %s.\n", vals[1])
+			return
+		}
+
+		if !isFuel(fuel) {
+			failf("%s won't burn.\n", fuel)
+			return
+		}
+	} else {
+		for _, f := range fuels {
+			if _, ok := inventory[f]; ok {
+				fuel = f
+				break
+			}
+		}
+
+		if fuel == "" {
+			fail("You need something to burn, like coal or wood.")
+			return
+		}
+	}
+
+	delete(inventory, name)
+	delete(inventory, fuel)
+	room.furnace = Smelt{output: output, done: turn + smeltTurns}
+	fmt.Printf("You put %s in the furnace and light it with %s.\n", name, fuel)
+	world.setRoom(coords, room)
+}
+
+func smeltOutput(input string) (string, bool) {
+	for output, recipe := range recipes {
+		if recipe.station == Furnace && recipe.ingredients[0] == input {
+			return output, true
+		}
+	}
+
+	return "", false
+}
+
+func isFuel(item string) bool {
+	for _, f := range fuels {
+		if f == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+// finishSmelt empties a furnace that finished while nobody was watching, so
+// coming back to it later finds the output waiting. It returns what was
+// left behind.
+func finishSmelt(coords RoomCoord) (string, bool) {
+	room := world.room(coords)
+	output := room.furnace.output
+
+	if output == "" || turn < room.furnace.done {
+		return "", false
+	}
+
+	room.items[output] = items[output]
+	room.furnace = Smelt{}
+	world.setRoom(coords, room)
+	return output, true
+}
+
+func updateFurnace() {
+	if output, ok := finishSmelt(getRoom(x, y, z, false)); ok {
+		fmt.Printf("The furnace dies down, leaving %s.\n", output)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSmelt(t *testing.T) {
+	here := newGame(t)
+	put(here, "a furnace")
+	give("some iron", "some coal")
+
+	smeltComm([]string{"iron"})
+
+	if _, ok := inventory["some iron"]; ok {
+		t.Error("iron still in the inventory after smelting it")
+	}
+
+	if _, ok := inventory["some coal"]; ok {
+		t.Error("coal still in the inventory after burning it")
+	}
+
+	turn += smeltTurns - 1
+
+	if _, ok := finishSmelt(here); ok {
+		t.Fatalf("smelt finished after %d turns, want %d", smeltTurns-1, smeltTurns)
+	}
+
+	turn += 1
+	output, ok := finishSmelt(here)
+
+	if !ok || output != "some iron ingots" {
+		t.Fatalf("finishSmelt() = %q, %v, want %q", output, ok, "some iron ingots")
+	}
+
+	if _, ok := world.room(here).items["some iron ingots"]; !ok {
+		t.Error("the ingots weren't left in the room")
+	}
+
+	if world.room(here).furnace.output != "" {
+		t.Error("the furnace is still burning after it finished")
+	}
+}
+
+func TestSmeltNeeds(t *testing.T) {
+	here := newGame(t)
+	give("some pork", "some coal")
+
+	smeltComm([]string{"pork"})
+
+	if world.room(here).furnace.output != "" || actionTurns != 0 {
+		t.Error("smelted without a furnace")
+	}
+
+	put(here, "a furnace")
+	delete(inventory, "some coal")
+	actionTurns = 1
+	smeltComm([]string{"pork"})
+
+	if world.room(here).furnace.output != "" || actionTurns != 0 {
+		t.Error("smelted without fuel")
+	}
+
+	if _, ok := inventory["some pork"]; !ok {
+		t.Error("lost the pork to a furnace with no fuel")
+	}
+
+	give("some stone")
+	actionTurns = 1
+	smeltComm([]string{"pork", "stone"})
+
+	if world.room(here).furnace.output != "" || actionTurns != 0 {
+		t.Error("smelted with stone for fuel")
+	}
+
+	give("some sticks")
+	smeltComm([]string{"pork", "sticks"})
+
+	if got := world.room(here).furnace.output; got != "some cooked pork" {
+		t.Errorf("furnace output = %q, want %q", got, "some cooked pork")
+	}
+}
+
+// A furnace keeps burning while the player is away, and whatever it made is
+// waiting for them when they come back.
+func TestSmeltWhileAway(t *testing.T) {
+	here := newGame(t)
+	put(here, "a furnace")
+	give("some sand", "some wood")
+	smeltComm([]string{"sand"})
+
+	x = 40
+	world.setRoom(RoomCoord{x: x}, Room{items: map[string]Item{}, valid: true})
+	turn += smeltTurns
+	world.unloadDistant(RoomCoord{x: x})
+	x = 0
+
+	lookComm([]string{})
+
+	if _, ok := world.room(here).items["some glass"]; !ok {
+		t.Error("the glass wasn't waiting in the furnace room")
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+)
+
+var (
+	goals = []string{
+		"a wooden pickaxe",
+		"a stone pickaxe",
+		"a stone sword",
+		"some torches",
+		"a furnace",
+		"an iron pickaxe",
+		"an iron sword",
+		"a diamond pickaxe",
+	}
+)
+
+func toolTypeName(toolType ToolType) string {
+	switch toolType {
+	case Pick:
+		return "pickaxe"
+	case Sword:
+		return "sword"
+	case Shovel:
+		return "shovel"
+	default:
+		return "tool"
+	}
+}
+
+func haveItem(item string) bool {
+	if _, ok := inventory[item]; ok {
+		return true
+	}
+
+	if iItem := items[item]; iItem.tool {
+		_, ok := bestTool(iItem.toolType, iItem.toolLevel)
+		return ok
+	}
+
+	return false
+}
+
+func hintComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if health <= maxHealth/2 {
+		for name, item := range inventory {
+			if item.food {
+				fmt.Printf("You're hurt. Try eating %s.\n", name)
+				return
+			}
+		}
+	}
+
+	if isDark(coords) {
+		if haveItem("some torches") || haveItem("a torch") {
+			fmt.Println("It's dark here. Try placing a torch.")
+		} else {
+			fmt.Println("It's dark here. Torches would help you see, or you could feel your way around.")
+		}
+
+		return
+	}
+
+	if y == 0 && !isSunny() && !haveItem("a wooden sword") {
+		fmt.Println("Monsters come out at night. A sword would help, or you could wait it out underground.")
+		return
+	}
+
+	for _, goal := range goals {
+		if step := nextStep(goal, room, map[string]bool{}); step != "" {
+			fmt.Println(step)
+			return
+		}
+	}
+
+	fmt.Println("You have the best tools there are. Go explore!")
+}
+
+func nextStep(item string, room Room, seen map[string]bool) string {
+	if haveItem(item) || seen[item] {
+		return ""
+	}
+
+	seen[item] = true
+	recipe, ok := recipes[item]
+
+	if !ok {
+		return gatherStep(item, room, seen)
+	}
+
+	for _, req := range recipe.ingredients {
+		if step := nextStep(req, room, seen); step != "" {
+			return step
+		}
+	}
+
+	if !hasStation(room, recipe.station) {
+		station := stationItem(recipe.station)
+
+		if _, ok := inventory[station]; ok {
+			return fmt.Sprintf("Place %s here and you could make %s.", station, item)
+		}
+
+		if step := nextStep(station, room, seen); step != "" {
+			return step
+		}
+	}
+
+	if recipe.station == Furnace {
+		return fmt.Sprintf("You could smelt %s into %s.", recipe.ingredients[0], item)
+	}
+
+	return fmt.Sprintf("You could craft %s.", item)
+}
+
+func gatherStep(item string, room Room, seen map[string]bool) string {
+	iItem := items[item]
+
+	if iItem.ore {
+		tool, ok := bestTool(iItem.toolType, iItem.toolLevel)
+
+		if !ok {
+			if step := nextStep(toolFor(iItem.toolType, iItem.toolLevel), room, seen); step != "" {
+				return step
+			}
+		}
+
+		if _, ok := room.items[item]; ok {
+			return fmt.Sprintf("There's %s here. Try mining it with %s.", item, tool)
+		}
+
+		return fmt.Sprintf("%s needs a %s; you have one. Keep exploring to find some.", capitalize(iItem.aliases[0]), toolTypeName(iItem.toolType))
+	}
+
+	if item == "some wood" {
+		if room.trees {
+			return "Try breaking a tree."
+		}
+
+		return "Find some trees and try breaking one."
+	}
+
+	if hint := gatherHint(item); hint != "" {
+		return fmt.Sprintf("To get %s, %s.", item, hint)
+	}
+
+	return fmt.Sprintf("Look around for %s.", item)
+}
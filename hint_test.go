@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+)
+
+func hint(t *testing.T) string {
+	return output(t, func() { hintComm([]string{}) })
+}
+
+// The hints walk the player up the first steps of the tech tree, one step
+// at a time.
+func TestHintProgression(t *testing.T) {
+	here := newGame(t)
+	room := world.room(here)
+	room.trees = true
+	world.setRoom(here, room)
+
+	steps := []struct {
+		have []string
+		want string
+	}{
+		{nil, "Try breaking a tree.\n"},
+		{[]string{"some wood"}, "You could craft some planks.\n"},
+		{[]string{"some planks"}, "You could craft some sticks.\n"},
+		{[]string{"some planks", "some sticks"}, "You could craft a crafting table.\n"},
+		{[]string{"some planks", "some sticks", "a crafting table"}, "Place a crafting table here and you could make a wooden pickaxe.\n"},
+	}
+
+	for _, step := range steps {
+		inventory = map[string]Item{}
+		give(step.have...)
+
+		if got := hint(t); got != step.want {
+			t.Errorf("hint holding %q = %q, want %q", step.have, got, step.want)
+		}
+	}
+
+	put(here, "a crafting table")
+
+	if got, want := hint(t), "You could craft a wooden pickaxe.\n"; got != want {
+		t.Errorf("hint at a crafting table = %q, want %q", got, want)
+	}
+}
+
+func TestHintMining(t *testing.T) {
+	here := newGame(t)
+	give("a wooden pickaxe")
+
+	if got, want := hint(t), "Stone needs a pickaxe; you have one. Keep exploring to find some.\n"; got != want {
+		t.Errorf("hint without stone nearby = %q, want %q", got, want)
+	}
+
+	put(here, "some stone")
+
+	if got, want := hint(t), "There's some stone here. Try mining it with a wooden pickaxe.\n"; got != want {
+		t.Errorf("hint next to stone = %q, want %q", got, want)
+	}
+}
+
+func TestHintDanger(t *testing.T) {
+	newGame(t)
+	health = maxHealth / 2
+	give("some pork")
+
+	if got, want := hint(t), "You're hurt. Try eating some pork.\n"; got != want {
+		t.Errorf("hint when hurt = %q, want %q", got, want)
+	}
+
+	health = maxHealth
+	y = -1
+	world.setRoom(RoomCoord{y: -1}, Room{items: map[string]Item{}, valid: true})
+
+	if got, want := hint(t), "It's dark here. Torches would help you see, or you could feel your way around.\n"; got != want {
+		t.Errorf("hint in the dark = %q, want %q", got, want)
+	}
+
+	y = 0
+
+	for isSunny() {
+		turn += 1
+	}
+
+	if got, want := hint(t), "Monsters come out at night. A sword would help, or you could wait it out underground.\n"; got != want {
+		t.Errorf("hint at night = %q, want %q", got, want)
+	}
+}
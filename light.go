@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+)
+
+const (
+	fullLight  = 15
+	lavaLight  = 15
+	torchLight = 14
+	twilight   = 8
+	spawnLight = 7
+	moonLight  = 4
+	spillLight = 4
+	seeLight   = 3
+)
+
+var torchTurns = 200
+
+func sunLight() int {
+	tod := int(getTimeOfDay())
+
+	if tod < 9 {
+		return fullLight
+	} else if tod == 9 || tod == len(dayCycle) {
+		return twilight
+	}
+
+	return moonLight
+}
+
+func torchLit(room Room) bool {
+	_, ok := room.items["a torch"]
+	return ok && turn < room.torchOut
+}
+
+// lightLevel is how brightly lit a room is, from 0 to 15. Sunlight only
+// reaches the surface, and a torch casts a little light through any open
+// passage into the rooms next to it.
+func lightLevel(coords RoomCoord) int {
+	room := world.room(coords)
+	level := 0
+
+	if coords.y == 0 {
+		level = sunLight()
+	}
+
+	if torchLit(room) && torchLight > level {
+		level = torchLight
+	}
+
+	if _, ok := room.items["some lava"]; ok && lavaLight > level {
+		level = lavaLight
+	}
+
+	for _, dir := range directions {
+		dx, dy, dz := dirOffset(dir)
+		adjCoords := RoomCoord{x: coords.x + dx, y: coords.y + dy, z: coords.z + dz}
+
+		if !room.exits.getExit(dir) || !world.hasRoom(adjCoords) {
+			continue
+		}
+
+		if torchLit(world.room(adjCoords)) && spillLight > level {
+			level = spillLight
+		}
+	}
+
+	return level
+}
+
+func isDark(coords RoomCoord) bool {
+	return lightLevel(coords) < seeLight
+}
+
+// A single torch's durability is how many turns it has left to burn, or
+// zero if it's never been lit.
+func burnTime(torch Item) int {
+	if torch.durability > 0 {
+		return torch.durability
+	}
+
+	return torchTurns
+}
+
+func placeTorch(coords RoomCoord, turns int) {
+	room := world.room(coords)
+	room.items["a torch"] = items["a torch"]
+	room.torchOut = turn + turns
+	world.setRoom(coords, room)
+}
+
+// putTorch sets one of the player's torches burning in the room they're in.
+func putTorch() {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if torchLit(room) {
+		fail("There's already a torch burning here.")
+		return
+	}
+
+	wasDark := isDark(coords)
+
+	turns, ok := useTorch()
+
+	if !ok {
+		fail("You don't have torches.")
+		return
+	}
+
+	placeTorch(coords, turns)
+
+	if wasDark {
+		fmt.Println("The cave lights up under the torchflame.")
+	} else if y == 0 && !isSunny() {
+		fmt.Println("The night gets a little brighter.")
+	} else {
+		fmt.Println("Placed.")
+	}
+}
+
+// pickUpTorch takes down the torch burning in a room. It carries on from
+// where it left off when it's placed again.
+func pickUpTorch(room Room) Item {
+	torch := room.items["a torch"]
+
+	if left := room.torchOut - turn; left < torchTurns {
+		torch.durability = left
+	}
+
+	return torch
+}
+
+func burnOut(coords RoomCoord) bool {
+	room := world.room(coords)
+
+	if _, ok := room.items["a torch"]; !ok || torchLit(room) {
+		return false
+	}
+
+	delete(room.items, "a torch")
+	world.setRoom(coords, room)
+	return true
+}
+
+// useTorch takes a torch from the player, either the single one they
+// carry or one from their bundle, and says how long it will burn for. A
+// bundle only holds so many.
+func useTorch() (int, bool) {
+	if torch, ok := inventory["a torch"]; ok {
+		delete(inventory, "a torch")
+		return burnTime(torch), true
+	}
+
+	torches, ok := inventory["some torches"]
+
+	if !ok {
+		return 0, false
+	}
+
+	torches.durability -= 1
+
+	if torches.durability <= 0 {
+		delete(inventory, "some torches")
+		fmt.Println("That was your last torch.")
+	} else {
+		inventory["some torches"] = torches
+	}
+
+	return torchTurns, true
+}
+
+// bundleTorch puts the player's single torch in with their bundle, as long
+// as it's never been lit.
+func bundleTorch() {
+	torch, ok := inventory["a torch"]
+
+	if _, bundle := inventory["some torches"]; !ok || !bundle || torch.durability > 0 {
+		return
+	}
+
+	delete(inventory, "a torch")
+	addTorches(1)
+}
+
+// addTorches adds n torches to the bundle the player is carrying. The
+// bundle's durability is how many torches are left in it.
+func addTorches(n int) {
+	torches, ok := inventory["some torches"]
+
+	if !ok {
+		torches = items["some torches"]
+		torches.durability = 0
+	}
+
+	torches.durability += n
+	inventory["some torches"] = torches
+}
+
+func burnTorches() {
+	if burnOut(getRoom(x, y, z, false)) {
+		fmt.Println("The torch flickers and burns out.")
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLightLevel(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+
+	if got := lightLevel(here); got != fullLight {
+		t.Errorf("light on the surface by day = %d, want %d", got, fullLight)
+	}
+
+	cave := RoomCoord{y: -1}
+	tunnel := RoomCoord{x: 1, y: -1}
+	world.setRoom(cave, Room{items: map[string]Item{}, valid: true})
+	connect(cave, "west")
+
+	if !isDark(cave) {
+		t.Error("a cave with no torch isn't dark")
+	}
+
+	placeTorch(cave, torchTurns)
+
+	if got := lightLevel(cave); got != torchLight {
+		t.Errorf("light in a torchlit cave = %d, want %d", got, torchLight)
+	}
+
+	if got := lightLevel(tunnel); got != spillLight {
+		t.Errorf("light next to a torchlit cave = %d, want %d", got, spillLight)
+	}
+
+	turn += torchTurns
+
+	if !isDark(cave) || !isDark(tunnel) {
+		t.Error("the caves are still lit after the torch burned down")
+	}
+}
+
+func TestTorchBurnsOut(t *testing.T) {
+	here := newGame(t)
+	placeTorch(here, 3)
+	turn += 2
+
+	if burnOut(here) {
+		t.Fatal("the torch burned out early")
+	}
+
+	turn += 1
+
+	if !burnOut(here) {
+		t.Fatal("the torch didn't burn out")
+	}
+
+	if _, ok := world.room(here).items["a torch"]; ok {
+		t.Error("the burnt out torch is still in the room")
+	}
+}
+
+// A torch that's taken down carries on from where it left off when it's
+// put back up, so moving it about doesn't make it last any longer.
+func TestTorchKeepsBurnTime(t *testing.T) {
+	here := newGame(t)
+	addTorches(2)
+
+	putTorch()
+	turn += 50
+	commands["take"]([]string{"torch"})
+
+	if got := inventory["a torch"].durability; got != torchTurns-50 {
+		t.Fatalf("the taken torch has %d turns left, want %d", got, torchTurns-50)
+	}
+
+	if got := inventory["some torches"].durability; got != 1 {
+		t.Errorf("the bundle has %d torches, want the 1 left", got)
+	}
+
+	putTorch()
+
+	if got := world.room(here).torchOut - turn; got != torchTurns-50 {
+		t.Errorf("the torch was put back with %d turns left, want %d", got, torchTurns-50)
+	}
+
+	if got := inventory["some torches"].durability; got != 1 {
+		t.Errorf("putting back the part-burnt torch used one from the bundle")
+	}
+}
+
+func TestDropTorch(t *testing.T) {
+	here := newGame(t)
+	addTorches(1)
+
+	dropComm("torch")
+
+	if !torchLit(world.room(here)) {
+		t.Fatal("the dropped torch isn't burning")
+	}
+
+	turn += 1
+
+	if burnOut(here) {
+		t.Error("the dropped torch burned out on the next turn")
+	}
+
+	if _, ok := inventory["some torches"]; ok {
+		t.Error("the bundle outlived its last torch")
+	}
+}
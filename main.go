@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
+	"os"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/TwiN/go-color"
 )
@@ -34,6 +38,20 @@ func hasRivers(biome int) bool {
 	return biome != 2 && biome != 4
 }
 
+func hasSand(biome int) bool {
+	return biome == 4
+}
+
+func hasWolves(biome int) bool {
+	return biome == 1 || biome == 6
+}
+
+func hasGrass(biome int) bool {
+	return biome == 0 || biome == 1 || biome == 5
+}
+
+const maxHealth = 10
+
 type ToolType int
 
 const (
@@ -43,87 +61,39 @@ const (
 	Shovel
 )
 
+type Slot int
+
+const (
+	NoSlot Slot = iota
+	Head
+	Chest
+	Legs
+	Feet
+)
+
 type Item struct {
-	droppable bool
-	desc      string
-	heavy     bool
-	creature  bool
-	drops     []string
-	aliases   []string
-	hitDrops  []string
-	monster   bool
-	nocturnal bool
-	material  bool
-	tool      bool
-	toolLevel int
-	toolType  ToolType
-	ore       bool
-	infinite  bool
-	food      bool
+	undroppable bool
+	desc        string
+	heavy       bool
+	aliases     []string
+	material    bool
+	tool        bool
+	toolLevel   int
+	toolType    ToolType
+	ore         bool
+	infinite    bool
+	food        bool
+	heal        int
+	slot        Slot
+	defense     int
+	durability  int
 }
 
 var (
 	items = map[string]Item{
 		"no tea": {
-			droppable: false,
-			desc:      "Pull youreslf together man.",
-		},
-		"a pig": {
-			heavy:    true,
-			creature: true,
-			drops:    []string{"some pork"},
-			aliases:  []string{"pig"},
-			desc:     "The pig has a square nose.",
-		},
-		"a cow": {
-			heavy:    true,
-			creature: true,
-			aliases:  []string{"cow"},
-			desc:     "The cow stares at you blankly.",
-		},
-		"a sheep": {
-			heavy:    true,
-			creature: true,
-			hitDrops: []string{"some wool"},
-			aliases:  []string{"sheep"},
-			desc:     "The sheep is fluffy.",
-		},
-		"a chicken": {
-			heavy:    true,
-			creature: true,
-			drops:    []string{"some chicken"},
-			aliases:  []string{"chicken"},
-			desc:     "The chicken looks delicious.",
-		},
-		"a creeper": {
-			heavy:    true,
-			creature: true,
-			monster:  true,
-			aliases:  []string{"creeper"},
-			desc:     "The creeper needs a hug.",
-		},
-		"a skeleton": {
-			heavy:     true,
-			creature:  true,
-			monster:   true,
-			aliases:   []string{"skeleton"},
-			nocturnal: true,
-			desc:      "The head bone's connected to the neck bone, the neck bone's connected to the chest bone, the chest bone's connected to the arm bone, the arm bone's connected to the bow, and the bow is pointed at you.",
-		},
-		"a zombie": {
-			heavy:     true,
-			creature:  true,
-			monster:   true,
-			aliases:   []string{"zombie"},
-			nocturnal: true,
-			desc:      "All he wants to do is eat your brains.",
-		},
-		"a spider": {
-			heavy:    true,
-			creature: true,
-			monster:  true,
-			aliases:  []string{"spider"},
-			desc:     "Dozens of eyes stare back at you.",
+			undroppable: true,
+			desc:        "Pull youreslf together man.",
 		},
 		"a cave entrance": {
 			heavy:   true,
@@ -138,7 +108,7 @@ var (
 		"a river": {
 			heavy:   true,
 			aliases: []string{"river"},
-			desc:    "The river flows majestically towards the horizon. It doesn't do anything else.",
+			desc:    "The river flows majestically towards the horizon. You could swim across, if you're not carrying too much.",
 		},
 		"some wood": {
 			aliases:  []string{"wood"},
@@ -155,16 +125,24 @@ var (
 		},
 		"a crafting table": {
 			aliases: []string{"crafting table", "craft table", "work bench", "workbench", "crafting bench", "table"},
-			desc:    "It's a crafting table. I shouldn't tell you this, but these don't actually do anything in this game, you can craft tools whenever you like.",
+			desc:    "It's a crafting table. Place it down and you can craft tools and other fancy things here.",
+		},
+		"a bed": {
+			aliases: []string{"bed"},
+			desc:    "It looks comfy. Place it down and you can sleep through the night, and you'll wake up here if you die.",
+		},
+		"a chest": {
+			aliases: []string{"chest", "box"},
+			desc:    "A sturdy wooden chest. Place it down and you can put things in it for safekeeping.",
 		},
 		"a furnace": {
 			aliases: []string{"furnace"},
-			desc:    "It's a furnace. Between you and me, these don't actually do anything in this game.",
+			desc:    "It's a furnace. Place it down and you can smelt ore and cook food in it, as long as you have something to burn.",
 		},
 		"a wooden pickaxe": {
 			aliases:   []string{"pickaxe", "pick", "wooden pick", "wooden pickaxe", "wood pick", "wood pickaxe"},
 			tool:      true,
-			toolLevel: 2,
+			toolLevel: 1,
 			toolType:  Pick,
 			desc:      "The pickaxe looks good for breaking stone and coal.",
 		},
@@ -245,6 +223,95 @@ var (
 			toolType:  Shovel,
 			desc:      "Good for digging holes.",
 		},
+		"a leather helmet": {
+			aliases:    []string{"leather helmet", "leather hat", "leather cap", "helmet"},
+			slot:       Head,
+			defense:    1,
+			durability: 10,
+			desc:       "Stiff leather, better than nothing.",
+		},
+		"a leather chestplate": {
+			aliases:    []string{"leather chestplate", "leather tunic", "leather chest plate", "chestplate"},
+			slot:       Chest,
+			defense:    2,
+			durability: 10,
+			desc:       "Stiff leather, better than nothing.",
+		},
+		"some leather leggings": {
+			aliases:    []string{"leather leggings", "leather pants", "leather trousers", "leggings"},
+			slot:       Legs,
+			defense:    2,
+			durability: 10,
+			desc:       "Stiff leather, better than nothing.",
+		},
+		"some leather boots": {
+			aliases:    []string{"leather boots", "boots"},
+			slot:       Feet,
+			defense:    1,
+			durability: 10,
+			desc:       "Stiff leather, better than nothing.",
+		},
+		"an iron helmet": {
+			aliases:    []string{"iron helmet", "iron hat", "iron cap", "helmet"},
+			slot:       Head,
+			defense:    2,
+			durability: 20,
+			desc:       "Solid iron. It clanks when you walk.",
+		},
+		"an iron chestplate": {
+			aliases:    []string{"iron chestplate", "iron tunic", "iron chest plate", "chestplate"},
+			slot:       Chest,
+			defense:    3,
+			durability: 20,
+			desc:       "Solid iron. It clanks when you walk.",
+		},
+		"some iron leggings": {
+			aliases:    []string{"iron leggings", "iron pants", "iron trousers", "leggings"},
+			slot:       Legs,
+			defense:    3,
+			durability: 20,
+			desc:       "Solid iron. It clanks when you walk.",
+		},
+		"some iron boots": {
+			aliases:    []string{"iron boots", "boots"},
+			slot:       Feet,
+			defense:    2,
+			durability: 20,
+			desc:       "Solid iron. It clanks when you walk.",
+		},
+		"a diamond helmet": {
+			aliases:    []string{"diamond helmet", "diamond hat", "diamond cap", "helmet"},
+			slot:       Head,
+			defense:    3,
+			durability: 40,
+			desc:       "Dazzling, and very hard to get through.",
+		},
+		"a diamond chestplate": {
+			aliases:    []string{"diamond chestplate", "diamond tunic", "diamond chest plate", "chestplate"},
+			slot:       Chest,
+			defense:    4,
+			durability: 40,
+			desc:       "Dazzling, and very hard to get through.",
+		},
+		"some diamond leggings": {
+			aliases:    []string{"diamond leggings", "diamond pants", "diamond trousers", "leggings"},
+			slot:       Legs,
+			defense:    4,
+			durability: 40,
+			desc:       "Dazzling, and very hard to get through.",
+		},
+		"some diamond boots": {
+			aliases:    []string{"diamond boots", "boots"},
+			slot:       Feet,
+			defense:    3,
+			durability: 40,
+			desc:       "Dazzling, and very hard to get through.",
+		},
+		"some leather": {
+			aliases:  []string{"leather", "hide"},
+			material: true,
+			desc:     "Tough cow hide. You could make armor out of it.",
+		},
 		"some coal": {
 			aliases:   []string{"coal"},
 			ore:       true,
@@ -257,6 +324,16 @@ var (
 			material: true,
 			desc:     "Why not build a mud hut?",
 		},
+		"some gravel": {
+			aliases:  []string{"gravel"},
+			material: true,
+			desc:     "Loose stones from the mountainside.",
+		},
+		"some snow": {
+			aliases:  []string{"snow"},
+			material: true,
+			desc:     "It's cold and wet, and it won't keep.",
+		},
 		"some stone": {
 			aliases:   []string{"stone", "cobblestone"},
 			material:  true,
@@ -274,6 +351,31 @@ var (
 			toolType:  Pick,
 			desc:      "That iron looks might strong, you'll need a stone pickaxe to mine it.",
 		},
+		"some iron ingots": {
+			aliases:  []string{"iron ingots", "iron ingot", "ingots", "ingot"},
+			material: true,
+			desc:     "Fresh from the furnace, ready to be made into tools.",
+		},
+		"some seeds": {
+			aliases: []string{"seeds", "wheat seeds", "seed"},
+			desc:    "Plant these with a shovel and they'll grow into wheat.",
+		},
+		"some wheat": {
+			aliases:  []string{"wheat"},
+			material: true,
+			desc:     "Golden and ripe. You could make bread out of it.",
+		},
+		"some sand": {
+			aliases:  []string{"sand"},
+			material: true,
+			infinite: true,
+			desc:     "It's coarse and rough and irritating, and it gets everywhere.",
+		},
+		"some glass": {
+			aliases:  []string{"glass"},
+			material: true,
+			desc:     "You can see right through it.",
+		},
 		"some diamond": {
 			aliases:   []string{"diamond", "diamonds"},
 			material:  true,
@@ -283,56 +385,178 @@ var (
 			desc:      "Sparkly, rare, and impossible to mine without an iron pickaxe.",
 		},
 		"some torches": {
-			aliases: []string{"torches", "torch"},
-			desc:    "These won't run out of a while.",
+			aliases:    []string{"torches", "torch"},
+			durability: 4,
+			desc:       "A bundle of torches. They won't last forever.",
 		},
 		"a torch": {
 			aliases: []string{"torch"},
 			desc:    "Fire, fire, burn so bright, won't you light my cave tonight?",
 		},
+		"some lava": {
+			aliases: []string{"lava"},
+			heavy:   true,
+			desc:    "It glows a fierce orange and lights up the whole cave. Best not touch it.",
+		},
+		"a ladder": {
+			aliases: []string{"ladder"},
+			desc:    "Place it in a shaft and you'll be able to climb up and down safely.",
+		},
+		"a door": {
+			aliases: []string{"door", "wooden door"},
+			desc:    "Place it in an exit to keep monsters out. You can open and close it.",
+		},
+		"a gate": {
+			aliases: []string{"gate", "fence gate"},
+			desc:    "Like a door, but draughtier.",
+		},
+		"a boat": {
+			aliases: []string{"boat"},
+			desc:    "Takes you across rivers, or downstream in a hurry.",
+		},
+		"some bones": {
+			aliases: []string{"bones", "bone"},
+			desc:    "A dog's favourite.",
+		},
+		"some string": {
+			aliases:  []string{"string", "spider silk", "silk"},
+			material: true,
+			desc:     "Sticky, but strong.",
+		},
+		"some feathers": {
+			aliases:  []string{"feathers", "feather"},
+			material: true,
+			desc:     "Light as a feather.",
+		},
+		"some arrows": {
+			aliases: []string{"arrows", "arrow"},
+			desc:    "Pointy end goes towards the enemy.",
+		},
+		"a bow": {
+			aliases: []string{"bow"},
+			desc:    "With some arrows, you could shoot things in the next room.",
+		},
 		"some wool": {
 			aliases:  []string{"wool"},
 			material: true,
 			desc:     "Soft and good for building.",
 		},
 		"some pork": {
-			aliases: []string{"pork", "porkchops"},
+			aliases: []string{"pork", "porkchops", "raw pork"},
 			food:    true,
-			desc:    "Delicious and nutricious.",
+			heal:    2,
+			desc:    "Delicious and nutricious. Even more so if you cook it first.",
 		},
 		"some chicken": {
-			aliases: []string{"chicken"},
+			aliases: []string{"chicken", "raw chicken"},
 			food:    true,
-			desc:    "Finger licking good.",
+			heal:    1,
+			desc:    "Finger licking good, once it's cooked.",
+		},
+		"some bread": {
+			aliases: []string{"bread", "loaf", "loaf of bread"},
+			food:    true,
+			heal:    5,
+			desc:    "Freshly baked.",
+		},
+		"some cooked pork": {
+			aliases: []string{"cooked pork", "cooked porkchops"},
+			food:    true,
+			heal:    6,
+			desc:    "Smells amazing.",
+		},
+		"some cooked chicken": {
+			aliases: []string{"cooked chicken"},
+			food:    true,
+			heal:    5,
+			desc:    "Crispy on the outside.",
+		},
+		"some fish": {
+			aliases: []string{"fish", "raw fish", "cod"},
+			food:    true,
+			heal:    1,
+			desc:    "Still flapping. It'd be better cooked.",
+		},
+		"some salmon": {
+			aliases: []string{"salmon", "raw salmon"},
+			food:    true,
+			heal:    2,
+			desc:    "A fine pink fish, fresh from the river.",
+		},
+		"some cooked fish": {
+			aliases: []string{"cooked fish", "cooked cod"},
+			food:    true,
+			heal:    5,
+			desc:    "Flaky and warm.",
+		},
+		"some cooked salmon": {
+			aliases: []string{"cooked salmon"},
+			food:    true,
+			heal:    6,
+			desc:    "Fit for a king.",
+		},
+		"an old boot": {
+			aliases: []string{"boot", "old boot"},
+			desc:    "Soggy, and it's only the left one.",
+		},
+		"a fishing rod": {
+			aliases: []string{"fishing rod", "rod"},
+			desc:    "Cast it into a river and see what bites.",
 		},
 	}
-	animals = []string{
-		"a pig", "a cow", "a sheep", "a chicken",
-	}
-	monsters = []string{
-		"a creeper", "a skeleton", "a zombie", "a spider",
-	}
-	recipes = map[string][]string{
-		"some planks":      []string{"some wood"},
-		"some sticks":      []string{"some planks"},
-		"a crafting table": []string{"some planks"},
-		"a furnace":        []string{"some stone"},
-		"some torches":     []string{"some sticks", "some coal"},
-
-		"a wooden pickaxe":  []string{"some planks", "some sticks"},
-		"a stone pickaxe":   []string{"some stone", "some sticks"},
-		"an iron pickaxe":   []string{"some iron", "some sticks"},
-		"a diamond pickaxe": []string{"some diamond", "some sticks"},
-
-		"a wooden sword":  []string{"some planks", "some sticks"},
-		"a stone sword":   []string{"some stone", "some sticks"},
-		"an iron sword":   []string{"some iron", "some sticks"},
-		"a diamond sword": []string{"some diamond", "some sticks"},
-
-		"a wooden shovel":  []string{"some planks", "some sticks"},
-		"a stone shovel":   []string{"some stone", "some sticks"},
-		"an iron shovel":   []string{"some iron", "some sticks"},
-		"a diamond shovel": []string{"some diamond", "some sticks"},
+	recipes = map[string]Recipe{
+		"some planks":      {ingredients: []string{"some wood"}},
+		"some sticks":      {ingredients: []string{"some planks"}},
+		"a crafting table": {ingredients: []string{"some planks"}},
+		"a furnace":        {station: CraftingTable, ingredients: []string{"some stone"}},
+		"a chest":          {station: CraftingTable, ingredients: []string{"some planks"}},
+		"a bed":            {station: CraftingTable, ingredients: []string{"some wool", "some planks"}},
+		"some bread":       {station: CraftingTable, ingredients: []string{"some wheat"}},
+		"a door":           {station: CraftingTable, ingredients: []string{"some planks"}},
+		"a gate":           {station: CraftingTable, ingredients: []string{"some sticks", "some planks"}},
+		"a boat":           {station: CraftingTable, ingredients: []string{"some planks", "some wood"}},
+		"a fishing rod":    {station: CraftingTable, ingredients: []string{"some sticks", "some string"}},
+		"a ladder":         {station: CraftingTable, ingredients: []string{"some sticks"}},
+		"a bow":            {station: CraftingTable, ingredients: []string{"some sticks", "some string"}},
+		"some arrows":      {station: CraftingTable, ingredients: []string{"some sticks", "some feathers"}},
+		"some torches":     {ingredients: []string{"some sticks", "some coal"}},
+
+		"a wooden pickaxe":  {station: CraftingTable, ingredients: []string{"some planks", "some sticks"}},
+		"a stone pickaxe":   {station: CraftingTable, ingredients: []string{"some stone", "some sticks"}},
+		"an iron pickaxe":   {station: CraftingTable, ingredients: []string{"some iron ingots", "some sticks"}},
+		"a diamond pickaxe": {station: CraftingTable, ingredients: []string{"some diamond", "some sticks"}},
+
+		"a wooden sword":  {station: CraftingTable, ingredients: []string{"some planks", "some sticks"}},
+		"a stone sword":   {station: CraftingTable, ingredients: []string{"some stone", "some sticks"}},
+		"an iron sword":   {station: CraftingTable, ingredients: []string{"some iron ingots", "some sticks"}},
+		"a diamond sword": {station: CraftingTable, ingredients: []string{"some diamond", "some sticks"}},
+
+		"a wooden shovel":  {station: CraftingTable, ingredients: []string{"some planks", "some sticks"}},
+		"a stone shovel":   {station: CraftingTable, ingredients: []string{"some stone", "some sticks"}},
+		"an iron shovel":   {station: CraftingTable, ingredients: []string{"some iron ingots", "some sticks"}},
+		"a diamond shovel": {station: CraftingTable, ingredients: []string{"some diamond", "some sticks"}},
+
+		"a leather helmet":      {station: CraftingTable, ingredients: []string{"some leather"}},
+		"a leather chestplate":  {station: CraftingTable, ingredients: []string{"some leather"}},
+		"some leather leggings": {station: CraftingTable, ingredients: []string{"some leather"}},
+		"some leather boots":    {station: CraftingTable, ingredients: []string{"some leather"}},
+
+		"an iron helmet":     {station: CraftingTable, ingredients: []string{"some iron ingots"}},
+		"an iron chestplate": {station: CraftingTable, ingredients: []string{"some iron ingots"}},
+		"some iron leggings": {station: CraftingTable, ingredients: []string{"some iron ingots"}},
+		"some iron boots":    {station: CraftingTable, ingredients: []string{"some iron ingots"}},
+
+		"a diamond helmet":      {station: CraftingTable, ingredients: []string{"some diamond"}},
+		"a diamond chestplate":  {station: CraftingTable, ingredients: []string{"some diamond"}},
+		"some diamond leggings": {station: CraftingTable, ingredients: []string{"some diamond"}},
+		"some diamond boots":    {station: CraftingTable, ingredients: []string{"some diamond"}},
+
+		"some iron ingots":    {station: Furnace, ingredients: []string{"some iron"}},
+		"some cooked pork":    {station: Furnace, ingredients: []string{"some pork"}},
+		"some cooked chicken": {station: Furnace, ingredients: []string{"some chicken"}},
+		"some glass":          {station: Furnace, ingredients: []string{"some sand"}},
+		"some cooked fish":    {station: Furnace, ingredients: []string{"some fish"}},
+		"some cooked salmon":  {station: Furnace, ingredients: []string{"some salmon"}},
 	}
 
 	goWest = []string{
@@ -353,10 +577,12 @@ var (
 	inventory = map[string]Item{
 		"no tea": items["no tea"],
 	}
-	turn       = 0
-	timeInRoom = 0
-	injured    = false
-	dayCycle   = []string{
+	spawn       = RoomCoord{}
+	turn        = 0
+	timeInRoom  = 0
+	actionTurns = 1
+	health      = maxHealth
+	dayCycle    = []string{
 		"It is daytime.",
 		"It is daytime.",
 		"It is daytime.",
@@ -373,17 +599,28 @@ var (
 		"It is night.",
 		"The sun is rising.",
 	}
-	roomMap = map[int]map[int]map[int]Room{}
+	world = newWorld(worldDir)
 )
 
 type Room struct {
-	biome    int
-	trees    bool
-	items    map[string]Item
-	exits    Exits
-	dark     bool
-	monsters int
-	valid    bool
+	biome   int
+	trees   bool
+	items   map[string]Item
+	exits   Exits
+	valid   bool
+	furnace Smelt
+	chest   map[string]Item
+	grave   map[string]Item
+	crop    Crop
+
+	entities []Entity
+	arrivals []string
+	arrived  int
+
+	graveExpires int
+	torchOut     int
+	river        string
+	bridge       bool
 }
 
 type Exits struct {
@@ -393,6 +630,9 @@ type Exits struct {
 	west  bool
 	down  bool
 	up    bool
+	kinds map[string]Passage
+	walls map[string]string
+	doors map[string]string
 }
 
 func (e Exits) getExit(s string) bool {
@@ -415,6 +655,14 @@ func (e Exits) getExit(s string) bool {
 }
 
 func (e *Exits) setExit(s string, v bool) {
+	// A wall only blocks the passage, so the ladder or steps behind it are
+	// still there when it's broken down again.
+	if v {
+		delete(e.walls, s)
+	} else if _, walled := e.walls[s]; !walled {
+		delete(e.kinds, s)
+	}
+
 	switch s {
 	case "north":
 		e.north = v
@@ -462,11 +710,19 @@ func (r Room) getExits() []string {
 }
 
 func getTimeOfDay() float64 {
-	return math.Mod(float64(turn/3), float64(len(dayCycle))) + 1.0
+	return timeOfDayAt(turn)
+}
+
+func timeOfDayAt(t int) float64 {
+	return math.Mod(float64(t/3), float64(len(dayCycle))) + 1.0
 }
 
 func isSunny() bool {
-	return getTimeOfDay() < 10
+	return isSunnyAt(turn)
+}
+
+func isSunnyAt(t int) bool {
+	return timeOfDayAt(t) < 10
 }
 
 type RoomCoord struct {
@@ -476,46 +732,30 @@ type RoomCoord struct {
 }
 
 func getRoom(x int, y int, z int, dontCreate bool) RoomCoord {
-	xVal, ok := roomMap[x]
-
-	if !ok {
-		xVal = make(map[int]map[int]Room)
-	}
-
-	yVal, ok := xVal[y]
-
-	if !ok {
-		yVal = make(map[int]Room)
-	}
+	coords := RoomCoord{x: x, y: y, z: z}
 
-	_, ok = yVal[z]
-
-	if !ok && !dontCreate {
+	if !world.hasRoom(coords) && !dontCreate {
 		room := Room{
 			items: make(map[string]Item),
-			exits: struct {
-				north bool
-				south bool
-				east  bool
-				west  bool
-				down  bool
-				up    bool
-			}{},
-			monsters: 0,
+			exits: Exits{},
 		}
-		roomMap[x][y][z] = Room{}
 
 		if y == 0 {
 			room.biome = rand.Intn(len(biomes))
 			room.trees = hasTrees(room.biome)
 
 			if rand.Intn(3) == 0 {
-				for i := 0; i < rand.Intn(1); i++ {
-					animal := animals[rand.Intn(len(animals))]
-					room.items[animal] = items[animal]
+				animal := randomChoice(animals)
+
+				for i := 0; i <= rand.Intn(maxHerdSize); i++ {
+					room.addEntity(newEntity(animal))
 				}
 			}
 
+			if hasWolves(room.biome) && rand.Intn(wolfOdds) == 0 {
+				room.addEntity(newEntity("a wolf"))
+			}
+
 			if rand.Intn(5) == 0 || hasStone(room.biome) {
 				room.items["some stone"] = items["some stone"]
 			}
@@ -524,7 +764,17 @@ func getRoom(x int, y int, z int, dontCreate bool) RoomCoord {
 				room.items["some coal"] = items["some coal"]
 			}
 
-			if rand.Intn(8) == 0 && hasRivers(room.biome) {
+			if hasSand(room.biome) {
+				room.items["some sand"] = items["some sand"]
+			}
+
+			if flow, ok := riverFrom(coords); ok {
+				room.river = flow
+			} else if rand.Intn(8) == 0 && hasRivers(room.biome) {
+				room.river = randomChoice(directions[:4])
+			}
+
+			if room.river != "" {
 				room.items["a river"] = items["a river"]
 			}
 
@@ -535,17 +785,21 @@ func getRoom(x int, y int, z int, dontCreate bool) RoomCoord {
 
 			if rand.Intn(8) == 0 {
 				room.exits.down = true
+				room.exits.setPassage("down", Steps)
 				room.items["a cave entrance"] = items["a cave entrance"]
 			}
 
-			room.valid = true
 		} else {
 			tryExit := func(sDir string, sOpp string, x int, y int, z int) {
 				coords := getRoom(x, y, z, true)
-				adj := roomMap[coords.x][coords.y][coords.z]
+				adj := world.room(coords)
 
 				if adj.valid {
 					room.exits.setExit(sDir, adj.exits.getExit(sOpp))
+
+					if adj.exits.getExit(sOpp) {
+						room.exits.setPassage(sDir, adj.exits.passage(sOpp))
+					}
 				} else {
 					if rand.Intn(3) == 0 {
 						room.exits.setExit(sDir, true)
@@ -555,10 +809,11 @@ func getRoom(x int, y int, z int, dontCreate bool) RoomCoord {
 
 			if y == -1 {
 				coords := getRoom(x, y+1, z, false)
-				above := roomMap[coords.x][coords.y][coords.z]
+				above := world.room(coords)
 
 				if above.exits.down {
 					room.exits.up = true
+					room.exits.setPassage("up", above.exits.passage("down"))
 					room.items["an exit to the surface"] = items["an exit to the surface"]
 				}
 			} else {
@@ -588,13 +843,46 @@ func getRoom(x int, y int, z int, dontCreate bool) RoomCoord {
 				room.items["some diamond"] = items["some diamond"]
 			}
 
-			room.dark = true
+			if y <= -2 && rand.Intn(12) == 0 {
+				room.items["some lava"] = items["some lava"]
+			}
 		}
 
-		roomMap[x][y][z] = room
+		room.valid = true
+		world.setRoom(coords, room)
 	}
 
-	return RoomCoord{x: x, y: y, z: z}
+	return coords
+}
+
+func findItem(t map[string]Item, name string) (string, bool) {
+	if _, ok := t[name]; ok {
+		return name, true
+	}
+
+	for n, item := range t {
+		for _, alias := range item.aliases {
+			if alias == name {
+				return n, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func bestTool(toolType ToolType, minLevel int) (string, bool) {
+	best := ""
+	bestLevel := minLevel - 1
+
+	for name, item := range inventory {
+		if item.tool && item.toolType == toolType && item.toolLevel > bestLevel {
+			best = name
+			bestLevel = item.toolLevel
+		}
+	}
+
+	return best, best != ""
 }
 
 func itemizeNum(t []int) string {
@@ -619,9 +907,9 @@ func itemizeStr(t []string) string {
 
 		if i < len(t)-1 {
 			if i < len(t)-2 {
-				text += " and "
-			} else {
 				text += ", "
+			} else {
+				text += " and "
 			}
 		}
 	}
@@ -631,7 +919,17 @@ func itemizeStr(t []string) string {
 
 var (
 	matches = map[string][]string{
-		"wait": {"wait"},
+		"wait": {
+			"wait for ([0-9]+) turns",
+			"wait ([0-9]+) turns",
+			"wait ([0-9]+)",
+			"wait",
+		},
+		"wait until": {
+			"wait until ([A-z ]+)",
+			"wait till ([A-z ]+)",
+			"wait for ([A-z ]+)",
+		},
 		"look": {
 			"look at the ([A-z ]+)",
 			"look at ([A-z ]+)",
@@ -656,6 +954,10 @@ var (
 		"dig": {
 			"dig ([A-z]+) using ([A-z ]+)",
 			"dig ([A-z]+) with ([A-z ]+)",
+			"dig some ([A-z]+) using ([A-z ]+)",
+			"dig some ([A-z]+) with ([A-z ]+)",
+			"dig some ([A-z]+)",
+			"dig the ([A-z]+)",
 			"dig ([A-z]+)",
 			"dig",
 		},
@@ -674,6 +976,21 @@ var (
 			"drop ([A-z ]+)",
 			"drop",
 		},
+		"store": {
+			"put the ([A-z ]+) in the chest",
+			"put ([A-z ]+) in the chest",
+			"put ([A-z ]+) in chest",
+			"store the ([A-z ]+)",
+			"store ([A-z ]+)",
+			"store",
+		},
+		"chest": {
+			"look in the chest",
+			"look in chest",
+			"look inside the chest",
+			"open the chest",
+			"open chest",
+		},
 		"place": {
 			"place the ([A-z ]+)",
 			"place ([A-z ]+)",
@@ -719,18 +1036,200 @@ var (
 			"make ([A-z ]+)",
 			"make",
 		},
+		"recipes": {
+			"recipes",
+			"recipe book",
+			"list recipes",
+		},
+		"recipe": {
+			"recipe for a ([A-z ]+)",
+			"recipe for some ([A-z ]+)",
+			"recipe for ([A-z ]+)",
+			"how do i make a ([A-z ]+)",
+			"how do i make some ([A-z ]+)",
+			"how do i make ([A-z ]+)",
+			"how do i craft a ([A-z ]+)",
+			"how do i craft some ([A-z ]+)",
+			"how do i craft ([A-z ]+)",
+			"recipe",
+		},
 		"build": {
 			"build ([A-z ]+) out of ([A-z ]+)",
 			"build ([A-z ]+) from ([A-z ]+)",
 			"build ([A-z ]+)",
 			"build",
 		},
+		"smelt": {
+			"smelt the ([A-z ]+) with the ([A-z ]+)",
+			"smelt ([A-z ]+) with ([A-z ]+)",
+			"smelt the ([A-z ]+)",
+			"smelt ([A-z ]+)",
+			"smelt",
+			"cook the ([A-z ]+) with the ([A-z ]+)",
+			"cook ([A-z ]+) with ([A-z ]+)",
+			"cook the ([A-z ]+)",
+			"cook ([A-z ]+)",
+			"cook",
+		},
 		"eat": {
 			"eat a ([A-z ]+)",
 			"eat the ([A-z ]+)",
 			"eat ([A-z ]+)",
 			"eat",
 		},
+		"hint": {
+			"hint",
+			"give me a hint",
+			"what now",
+			"what next",
+			"what should i do",
+		},
+		"sleep": {
+			"sleep in the bed",
+			"sleep in bed",
+			"go to sleep",
+			"go to bed",
+			"sleep",
+		},
+		"wear": {
+			"wear the ([A-z ]+)",
+			"wear ([A-z ]+)",
+			"put on the ([A-z ]+)",
+			"put on ([A-z ]+)",
+			"equip the ([A-z ]+)",
+			"equip ([A-z ]+)",
+			"wear",
+		},
+		"remove": {
+			"remove the ([A-z ]+)",
+			"remove ([A-z ]+)",
+			"unequip the ([A-z ]+)",
+			"unequip ([A-z ]+)",
+			"remove",
+		},
+		"open": {
+			"open the door",
+			"open the gate",
+			"open the door to the ([A-z]+)",
+			"open the door ([A-z]+)",
+			"open door ([A-z]+)",
+			"open the ([A-z]+) door",
+			"open ([A-z]+) door",
+			"open the gate to the ([A-z]+)",
+			"open the gate ([A-z]+)",
+			"open gate ([A-z]+)",
+			"open door",
+			"open gate",
+		},
+		"close": {
+			"close the door",
+			"close the gate",
+			"close the door to the ([A-z]+)",
+			"close the door ([A-z]+)",
+			"close door ([A-z]+)",
+			"close the ([A-z]+) door",
+			"close ([A-z]+) door",
+			"close the gate to the ([A-z]+)",
+			"close the gate ([A-z]+)",
+			"close gate ([A-z]+)",
+			"close door",
+			"close gate",
+			"shut the door",
+			"shut door",
+		},
+		"swim": {
+			"swim across the river",
+			"swim across",
+			"swim the river",
+			"swim",
+		},
+		"cross": {
+			"cross the river",
+			"cross river",
+			"cross the bridge",
+			"cross",
+		},
+		"row": {
+			"row downstream",
+			"sail downstream",
+			"float downstream",
+			"row",
+		},
+		"fish": {
+			"fish in the river",
+			"go fishing",
+			"cast a line",
+			"fish",
+		},
+		"feel": {
+			"feel around",
+			"feel your way",
+			"feel for exits",
+			"grope around",
+			"feel",
+		},
+		"hold": {
+			"hold the ([A-z ]+)",
+			"hold ([A-z ]+)",
+			"wield the ([A-z ]+)",
+			"wield ([A-z ]+)",
+			"hold",
+			"wield",
+		},
+		"equipment": {
+			"check equipment",
+			"equipment",
+			"armor",
+			"armour",
+		},
+		"shoot": {
+			"shoot the ([A-z ]+) to the (north|south|east|west|up|down)",
+			"shoot ([A-z ]+) to the (north|south|east|west|up|down)",
+			"shoot the ([A-z ]+) (north|south|east|west|up|down)",
+			"shoot ([A-z ]+) (north|south|east|west|up|down)",
+			"shoot at the ([A-z ]+)",
+			"shoot the ([A-z ]+)",
+			"shoot ([A-z ]+)",
+			"shoot",
+		},
+		"feed": {
+			"feed the ([A-z ]+) with the ([A-z ]+)",
+			"feed the ([A-z ]+) with some ([A-z ]+)",
+			"feed the ([A-z ]+) with ([A-z ]+)",
+			"feed ([A-z ]+) with ([A-z ]+)",
+			"feed the ([A-z ]+)",
+			"feed ([A-z ]+)",
+			"feed",
+			"tame the ([A-z ]+) with the ([A-z ]+)",
+			"tame the ([A-z ]+) with ([A-z ]+)",
+			"tame ([A-z ]+) with ([A-z ]+)",
+			"tame the ([A-z ]+)",
+			"tame ([A-z ]+)",
+			"tame",
+		},
+		"plant": {
+			"plant the ([A-z ]+)",
+			"plant some ([A-z ]+)",
+			"plant ([A-z ]+)",
+			"plant",
+			"sow the ([A-z ]+)",
+			"sow some ([A-z ]+)",
+			"sow ([A-z ]+)",
+			"sow",
+		},
+		"harvest": {
+			"harvest the ([A-z ]+)",
+			"harvest ([A-z ]+)",
+			"harvest",
+		},
+		"recover": {
+			"recover my items",
+			"recover my belongings",
+			"recover my stuff",
+			"recover items",
+			"recover belongings",
+			"recover",
+		},
 		"help": {
 			"help me",
 			"help",
@@ -775,78 +1274,82 @@ var (
 
 			fmt.Println(randomChoice(responses))
 		},
-		"wait": func(_ []string) {
-			fmt.Println("Time passes...")
-		},
-		"look": func(vals []string) {
-			var target string
+		"wait":       waitComm,
+		"wait until": waitUntilComm,
+		"eat": func(vals []string) {
+			var item string
 
 			if len(vals) == 0 {
-				target = ""
+				item = ""
 			} else {
-				target = vals[0]
+				item = vals[0]
 			}
 
-			coords := getRoom(x, y, z, false)
-			room := roomMap[coords.x][coords.y][coords.z]
-
-			if room.dark {
-				fmt.Println("It is pitch dark.")
+			if item == "" {
+				fail("Eat what?")
 				return
 			}
 
-			if target == "" {
-				if y == 0 {
-					fmt.Printf("You are standing %s. ", biomes[room.biome])
-					fmt.Println(dayCycle[int(getTimeOfDay())])
-				} else {
-					fmt.Print("You are underground. ")
-					exits := room.getExits()
+			name, ok := findItem(inventory, item)
 
-					if len(exits) != 0 {
-						fmt.Printf("You can travel %s.", itemizeStr(exits))
-					} else {
-						fmt.Println()
-					}
-				}
-
-				if len(room.items) > 0 {
-					items := []string{}
+			if !ok {
+				failf("You don't have any %s.\n", item)
+				return
+			}
 
-					for i := range room.items {
-						items = append(items, i)
-					}
+			iItem := inventory[name]
 
-					fmt.Printf("There is %s here.\n", itemizeStr(items))
-				}
+			if !iItem.food {
+				failf("You can't eat %s.\n", name)
+				return
+			}
 
-				if room.trees {
-					fmt.Println("There are trees here.")
-				}
-			} else {
-				if room.trees && (target == "tree" || target == "trees") {
-					fmt.Println("The trees look easy to break.")
-				} else if target == "self" || target == "myself" {
-					fmt.Println("Very handsome.")
-				} else {
-					item, ok := room.items[target]
+			delete(inventory, name)
+			fmt.Println("That was delicious!")
 
-					if !ok {
-						item, ok = inventory[target]
-					}
+			if health < maxHealth {
+				health += iItem.heal
 
-					if ok {
-						if item.desc == "" {
-							fmt.Printf("You see nothing special about %s.\n", target)
-						} else {
-							fmt.Println(item.desc)
-						}
-					} else {
-						fmt.Printf("You don't see any %s here.\n", target)
-					}
+				if health >= maxHealth {
+					health = maxHealth
+					fmt.Println("You are fully healed.")
+				} else {
+					fmt.Println("You feel a little better.")
 				}
 			}
 		},
+		"craft":     craftComm,
+		"recipes":   recipesComm,
+		"hint":      hintComm,
+		"store":     storeComm,
+		"chest":     chestComm,
+		"sleep":     sleepComm,
+		"recover":   recoverComm,
+		"plant":     plantComm,
+		"feed":      feedComm,
+		"shoot":     shootComm,
+		"wear":      wearComm,
+		"remove":    removeComm,
+		"equipment": equipmentComm,
+		"hold":      holdComm,
+		"feel":      feelComm,
+		"fish":      fishComm,
+		"swim":      swimComm,
+		"cross":     crossComm,
+		"row":       rowComm,
+		"build":     buildComm,
+		"open":      openComm,
+		"close":     closeComm,
+		"harvest":   harvestComm,
+		"recipe":    recipeComm,
+		"smelt":     smeltComm,
+		"help": func(_ []string) {
+			fmt.Println("Welcome to adventure, the greatest text adventure game on the command line. To get around the world, type actions, and the adventure will be read back to you. The actions available to you are go, wait, look, inspect, feel, inventory, equipment, wear, remove, hold, take, drop, place, open, close, build, swim, cross, row, fish, store, punch, attack, shoot, mine, dig, craft, recipes, smelt, cook, eat, feed, tame, plant, harvest, sleep, recover, hint and exit.")
+		},
+		"exit": func(_ []string) {
+			running = false
+		},
+		"look": lookComm,
 		"go": func(vals []string) {
 			var dir string
 
@@ -857,10 +1360,10 @@ var (
 			}
 
 			coords := getRoom(x, y, z, false)
-			room := roomMap[coords.x][coords.y][coords.z]
+			room := world.room(coords)
 
 			if dir == "" {
-				fmt.Println("Go where?")
+				fail("Go where?")
 				return
 			}
 
@@ -868,7 +1371,7 @@ var (
 				if dir == "west" {
 					nGoWest += 1
 
-					if nGoWest > len(goWest) {
+					if nGoWest >= len(goWest) {
 						nGoWest = 0
 					}
 
@@ -880,30 +1383,25 @@ var (
 				}
 			}
 
-			if !room.exits.getExit(dir) {
-				fmt.Println("You can't go that way.")
+			if (dir == "downstream" || dir == "upstream") && room.river != "" {
+				if dir == "downstream" {
+					dir = room.river
+				} else {
+					dir = opposite(room.river)
+				}
+			}
+
+			if name, ok := room.exits.door(dir); ok && !room.exits.getExit(dir) {
+				failf("The %s to the %s is closed.\n", items[name].aliases[0], dir)
 				return
 			}
 
-			if dir == "north" {
-				z += 1
-			} else if dir == "south" {
-				z -= 1
-			} else if dir == "east" {
-				x -= 1
-			} else if dir == "west" {
-				x += 1
-			} else if dir == "up" {
-				y += 1
-			} else if dir == "down" {
-				y -= 1
-			} else {
-				fmt.Println("I don't understand that direction.")
+			if !room.exits.getExit(dir) {
+				fail("You can't go that way.")
 				return
 			}
 
-			timeInRoom = 0
-			lookComm([]string{})
+			walk(dir)
 		},
 		"dig": func(vals []string) {
 			var dir string
@@ -921,121 +1419,127 @@ var (
 			}
 
 			coords := getRoom(x, y, z, false)
-			room := roomMap[coords.x][coords.y][coords.z]
+			room := world.room(coords)
 
 			if dir == "" {
-				fmt.Println("Dig where?")
+				fail("Dig where?")
+				return
+			}
+
+			if name, ok := findItem(inventory, tool); ok {
+				tool = name
+			}
+
+			if isGround(dir) {
+				digGround(room, dir, tool)
 				return
 			}
 
 			var iTool Item
 			var fTool bool
 
+			loose := y == 0 && dir == "down"
+
+			if tool == "" {
+				tool, _ = digTool(loose)
+			}
+
 			if tool != "" {
 				iTool, fTool = inventory[tool]
 
 				if !fTool {
-					fmt.Printf("You're not carrying a %s.\n", tool)
+					failf("You're not carrying a %s.\n", tool)
 					return
 				}
 			}
 
-			actuallyDigging := !room.exits.getExit(dir)
+			if name, ok := room.exits.door(dir); ok && !room.exits.getExit(dir) {
+				failf("The %s to the %s is closed.\n", items[name].aliases[0], dir)
+				return
+			}
 
-			if actuallyDigging {
-				if !fTool || iTool.toolType != Pick {
-					fmt.Println("You need to use a pickaxe to dig through stone.")
-					return
-				}
+			if dir == "up" && y == 0 {
+				fail("You can't dig that way.")
+				return
 			}
 
-			setCoordExit := func(x int, y int, z int, exit string, val bool) {
-				tmp := getRoom(x, y, z, false)
-				tmp2 := roomMap[tmp.x][tmp.y][tmp.z]
-				tmp2.exits.setExit(exit, val)
-				roomMap[tmp.x][tmp.y][tmp.z] = tmp2
-			}
-
-			if dir == "north" {
-				room.exits.north = true
-				z += 1
-				setCoordExit(x, y, z, "south", true)
-			} else if dir == "south" {
-				room.exits.south = true
-				z -= 1
-				setCoordExit(x, y, z, "north", true)
-			} else if dir == "east" {
-				room.exits.east = true
-				x -= 1
-				setCoordExit(x, y, z, "west", true)
-			} else if dir == "west" {
-				room.exits.west = true
-				x += 1
-				setCoordExit(x, y, z, "east", true)
-			} else if dir == "up" {
-				if y == 0 {
-					fmt.Println("You can't dig that way.")
-					return
-				}
+			if dir == "down" && y <= -3 {
+				fail("You hit bedrock.")
+				return
+			}
 
-				room.exits.up = true
+			if dx, dy, dz := dirOffset(dir); dx == 0 && dy == 0 && dz == 0 {
+				fail("I don't understand that direction.")
+				return
+			}
 
-				if y == -1 {
-					room.items["an exit to the surface"] = items["an exit to the surface"]
-				}
+			vertical := dir == "up" || dir == "down"
 
-				y += 1
-				coords1 := getRoom(x, y, z, false)
-				room1 := roomMap[coords1.x][coords1.y][coords1.z]
-				room1.exits.down = true
+			// Digging where there's already a way through just walks
+			// along it, unless it's a bare shaft that needs steps cut.
+			if room.exits.getExit(dir) && !(vertical && room.exits.passage(dir) == Open) {
+				walk(dir)
+				return
+			}
 
-				if y == 0 {
-					room1.items["a cave entrance"] = items["a cave entrance"]
-				}
+			usable := iTool.toolType == Pick || (loose && iTool.toolType == Shovel)
 
-				roomMap[coords1.x][coords1.y][coords1.z] = room
-			} else if dir == "down" {
-				if y <= -3 {
-					fmt.Println("You hit bedrock.")
-					return
+			if !fTool || !usable {
+				if loose {
+					fail("You need a shovel or a pickaxe to dig into the ground.")
+				} else {
+					fail("You need to use a pickaxe to dig through stone.")
 				}
 
-				room.exits.down = true
+				return
+			}
 
-				if y == 0 {
-					room.items["a cave entrance"] = items["a cave entrance"]
-				}
+			if riverInWay(room, dir) {
+				fail("You can't dig across the river.")
+				return
+			}
 
-				y -= 1
+			coords1 := neighbour(dir)
+			room1 := world.room(coords1)
+			room.exits.setExit(dir, true)
+			room1.exits.setExit(opposite(dir), true)
 
-				coords1 := getRoom(x, y, z, false)
-				room1 := roomMap[coords1.x][coords1.y][coords1.z]
-				room1.exits.up = true
+			if vertical {
+				room.exits.setPassage(dir, Steps)
+				room1.exits.setPassage(opposite(dir), Steps)
+			}
 
-				if y == -1 {
-					room.items["an exit to the surface"] = items["an exit to the surface"]
-				}
+			ground := groundMaterial(room.biome)
+			surface := false
 
-				roomMap[coords1.x][coords1.y][coords1.z] = room1
-			} else {
-				fmt.Println("I don't understand that direction.")
-				return
+			if dir == "down" && y == 0 {
+				room.items["a cave entrance"] = items["a cave entrance"]
+				room1.items["an exit to the surface"] = items["an exit to the surface"]
+				surface = true
+			} else if dir == "up" && y == -1 {
+				room.items["an exit to the surface"] = items["an exit to the surface"]
+				room1.items["a cave entrance"] = items["a cave entrance"]
+				ground = groundMaterial(room1.biome)
+				surface = true
 			}
 
-			if actuallyDigging {
-				if (dir == "down" && y == -1) || (dir == "up" && y == 0) {
-					inventory["some dirt"] = items["some dirt"]
-					inventory["some stone"] = items["some stone"]
-					fmt.Printf("You dig %s using %s and collect some dirt and stone.\n", dir, tool)
-				} else {
-					inventory["some stone"] = items["some stone"]
-					fmt.Printf("You dig %s using %s and collect some stone.\n", dir, tool)
-				}
+			world.setRoom(coords, room)
+			world.setRoom(coords1, room1)
+
+			if iTool.toolType == Shovel {
+				inventory[ground] = items[ground]
+				fmt.Printf("You dig %s using %s and collect %s.\n", dir, tool, ground)
+			} else if surface {
+				inventory[ground] = items[ground]
+				inventory["some stone"] = items["some stone"]
+				fmt.Printf("You dig %s using %s and collect %s and stone.\n", dir, tool, ground)
+			} else {
+				inventory["some stone"] = items["some stone"]
+				fmt.Printf("You dig %s using %s and collect some stone.\n", dir, tool)
 			}
 
-			timeInRoom = 0
-			lookComm([]string{})
-			roomMap[coords.x][coords.y][coords.z] = room
+			actionTurns = digTurns(iTool, loose)
+			walk(dir)
 		},
 		"inventory": func(_ []string) {
 			vals := []string{}
@@ -1045,6 +1549,10 @@ var (
 			}
 
 			fmt.Printf("You are carrying %s.\n", itemizeStr(vals))
+
+			if name, ok := holding(); ok {
+				fmt.Printf("You are holding %s.\n", name)
+			}
 		},
 		"drop": func(vals []string) {
 			var item string
@@ -1067,33 +1575,27 @@ var (
 			}
 
 			if item == "" {
-				fmt.Println("Place what?")
+				fail("Place what?")
 				return
 			}
 
-			if item == "torch" || item == "a torch" {
-				coords := getRoom(x, y, z, false)
-				room := roomMap[coords.x][coords.y][coords.z]
-				_, sTorches := inventory["some torches"]
-				_, torch := inventory["a torch"]
-
-				if sTorches || torch {
-					delete(inventory, "a torch")
-					room.items["a torch"] = items["a torch"]
-
-					if room.dark {
-						fmt.Println("The cave lights up under the torchflame.")
-						room.dark = false
-					} else if y == 0 && !isSunny() {
-						fmt.Println("The night gets a little brighter.")
-					} else {
-						fmt.Println("Placed.")
-					}
+			if strings.HasPrefix(item, "ladder") || strings.HasPrefix(item, "a ladder") {
+				placeLadder(item)
+				return
+			}
+
+			if material, dir := splitDirection(item); material != "" && dir != "" {
+				if name, ok := findItem(inventory, material); ok && isDoor(name) {
+					placeDoor(name, dir)
 				} else {
-					fmt.Println("You don't have torches.")
+					placeWall(material, dir)
 				}
 
-				roomMap[coords.x][coords.y][coords.z] = room
+				return
+			}
+
+			if item == "torch" || item == "a torch" {
+				putTorch()
 				return
 			}
 
@@ -1109,45 +1611,68 @@ var (
 			}
 
 			if item == "" {
-				fmt.Println("Take what?")
+				fail("Take what?")
 				return
 			}
 
+			for _, suffix := range []string{" from the chest", " from chest"} {
+				if name, ok := strings.CutSuffix(item, suffix); ok {
+					withdrawComm(name)
+					return
+				}
+			}
+
 			coords := getRoom(x, y, z, false)
-			room := roomMap[coords.x][coords.y][coords.z]
+			finishSmelt(coords)
+			room := world.room(coords)
+
+			if name, ok := findItem(room.items, item); ok {
+				item = name
+			}
+
 			iItem, fItem := room.items[item]
 
-			if fItem {
+			if _, ok := findEntity(room, item); ok && !fItem {
+				failf("You can't carry %s.\n", item)
+			} else if fItem {
 				if iItem.heavy {
-					fmt.Printf("You can't carry %s.\n", item)
+					failf("You can't carry %s.\n", item)
+				} else if item == "a furnace" && room.furnace.output != "" {
+					fail("The furnace is too hot to pick up.")
+				} else if item == "a chest" && len(room.chest) > 0 {
+					fail("You'll need to empty the chest first.")
 				} else if iItem.ore {
-					fmt.Println("You need to mine this ore.")
+					fail("You need to mine this ore.")
 				} else {
+					if item == "a torch" {
+						iItem = pickUpTorch(room)
+					}
+
 					if !iItem.infinite {
 						delete(room.items, item)
 					}
 
 					inventory[item] = iItem
 
-					_, sTorches := inventory["some torches"]
-					_, torch := inventory["torch"]
-
-					if sTorches && torch {
-						delete(inventory, "a torch")
+					if item == "a bed" && spawn == coords {
+						spawn = RoomCoord{}
 					}
 
-					if item == "a torch" && y < 0 {
-						room.dark = true
+					bundleTorch()
+
+					world.setRoom(coords, room)
+
+					if item == "a torch" && isDark(coords) {
 						fmt.Println("The cave plunges into darkness.")
 					} else {
 						fmt.Println("Taken.")
 					}
 				}
 			} else {
-				fmt.Printf("You don't see a %s here.\n", item)
+				failf("You don't see a %s here.\n", item)
 			}
 
-			roomMap[coords.x][coords.y][coords.z] = room
+			world.setRoom(coords, room)
 		},
 		"mine": func(vals []string) {
 			var item string
@@ -1165,13 +1690,19 @@ var (
 			}
 
 			if item == "" {
-				fmt.Println("Mine what?")
+				fail("Mine what?")
 				return
 			}
 
 			if tool == "" {
-				fmt.Printf("Mine %s with what?\n", item)
-				return
+				toolType := Pick
+				room := world.room(getRoom(x, y, z, false))
+
+				if name, ok := findItem(room.items, item); ok && room.items[name].ore {
+					toolType = room.items[name].toolType
+				}
+
+				tool, _ = pickTool(toolType)
 			}
 
 			cbreakComm(item, tool)
@@ -1192,10 +1723,14 @@ var (
 			}
 
 			if item == "" {
-				fmt.Println("Attack what?")
+				fail("Attack what?")
 				return
 			}
 
+			if tool == "" {
+				tool, _ = pickTool(Sword)
+			}
+
 			cbreakComm(item, tool)
 		},
 		"cbreak": func(vals []string) {
@@ -1220,6 +1755,7 @@ var (
 
 func doCommand(text string) {
 	if text == "" {
+		actionTurns = 0
 		commands["noinput"]([]string{})
 		return
 	}
@@ -1230,7 +1766,15 @@ func doCommand(text string) {
 			captures := re.FindStringSubmatch(text)
 
 			if len(captures) != 0 {
-				fnCommand := commands[command]
+				fnCommand, ok := commands[command]
+
+				if !ok {
+					fnCommand = commands["badinput"]
+				}
+
+				if !ok || freeActions[command] {
+					actionTurns = 0
+				}
 
 				if len(captures) == 1 && captures[0] == match {
 					fnCommand([]string{})
@@ -1243,41 +1787,224 @@ func doCommand(text string) {
 		}
 	}
 
+	actionTurns = 0
 	commands["badinput"]([]string{})
 }
 
+// walk moves the player through the way out of their room in direction dir,
+// which the caller has already checked is open.
+func walk(dir string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if !crossRiver(room, dir) {
+		return
+	}
+
+	blind := isDark(coords)
+
+	if dir == "up" {
+		if !climbable(room.exits.passage("up")) {
+			fail("The shaft is too steep to climb. You need a ladder, or steps dug into the rock.")
+			return
+		}
+
+		y += 1
+	} else if dir == "down" {
+		if !drop(room) {
+			return
+		}
+	} else {
+		dx, _, dz := dirOffset(dir)
+		x += dx
+		z += dz
+	}
+
+	timeInRoom = 0
+	arrive(dir)
+	world.unloadDistant(RoomCoord{x: x, y: y, z: z})
+	lookComm([]string{})
+
+	if blind || isDark(getRoom(x, y, z, false)) {
+		stumble()
+	}
+}
+
 func lookComm(vals []string) {
+	var target string
+
+	if len(vals) == 0 {
+		target = ""
+	} else {
+		target = vals[0]
+	}
+
+	coords := getRoom(x, y, z, false)
+	burnOut(coords)
+	finishSmelt(coords)
+	room := world.room(coords)
+	light := lightLevel(coords)
+
+	if light < seeLight {
+		fmt.Println("It is pitch dark.")
+		return
+	}
+
+	dim := light < spawnLight
+
+	if target == "" {
+		if y == 0 {
+			fmt.Printf("You are standing %s. ", biomes[room.biome])
+			fmt.Println(dayCycle[int(getTimeOfDay())-1])
+		} else {
+			fmt.Print("You are underground. ")
+			exits := room.getExits()
+
+			if len(exits) != 0 {
+				fmt.Printf("You can travel %s.\n", itemizeStr(exits))
+			} else {
+				fmt.Println()
+			}
+		}
+
+		describePassages(room)
+		describeWalls(room)
+		describeDoors(room)
+
+		if isShelter(coords) {
+			fmt.Println("You're shut in safe and sound. Nothing can spawn in here.")
+		}
+
+		if len(room.items) > 0 || (len(room.entities) > 0 && !dim) {
+			items := []string{}
+
+			for i := range room.items {
+				items = append(items, i)
+			}
+
+			if !dim {
+				items = append(items, describeEntities(room.entities)...)
+			}
 
+			fmt.Printf("There is %s here.\n", itemizeStr(items))
+		}
+
+		if dim && len(room.entities) > 0 {
+			fmt.Println("Something is moving in the shadows.")
+		}
+
+		if len(pets) > 0 {
+			fmt.Printf("You are followed by %s.\n", itemizeStr(describeEntities(pets)))
+		}
+
+		if room.arrived >= turn-1 && !dim {
+			for _, text := range room.arrivals {
+				fmt.Println(text)
+			}
+		}
+
+		if room.trees {
+			fmt.Println("There are trees here.")
+		}
+
+		describeRiver(room)
+
+		if room.furnace.output != "" {
+			fmt.Println("The furnace is burning.")
+		}
+
+		if graveActive(room) {
+			fmt.Println("Your belongings are scattered here.")
+		}
+
+		if room.crop.planted {
+			growCrop(&room)
+
+			if room.crop.ripe() {
+				fmt.Println("There is some wheat here, ready to harvest.")
+			} else {
+				fmt.Println("There is some wheat growing here.")
+			}
+
+			world.setRoom(coords, room)
+		}
+	} else {
+		if room.trees && (target == "tree" || target == "trees") {
+			fmt.Println("The trees look easy to break.")
+		} else if target == "self" || target == "myself" {
+			fmt.Println("Very handsome.")
+		} else if i, ok := findEntity(room, target); ok && dim {
+			fmt.Println("It's too dim to make it out.")
+		} else if ok {
+			fmt.Println(room.entities[i].kind().desc)
+		} else {
+			item, ok := room.items[target]
+
+			if !ok {
+				item, ok = inventory[target]
+			}
+
+			if ok {
+				if item.desc == "" {
+					fmt.Printf("You see nothing special about %s.\n", target)
+				} else {
+					fmt.Println(item.desc)
+				}
+			} else {
+				fmt.Printf("You don't see any %s here.\n", target)
+			}
+		}
+	}
 }
 
 func dropComm(item string) {
 	if item == "" {
-		fmt.Println("Drop what?")
+		fail("Drop what?")
+		return
+	}
+
+	if item == "torch" || item == "a torch" {
+		putTorch()
 		return
 	}
 
 	coords := getRoom(x, y, z, false)
-	room := roomMap[coords.x][coords.y][coords.z]
+	room := world.room(coords)
+
+	if name, ok := findItem(inventory, item); ok {
+		item = name
+	}
+
 	iItem, fItem := inventory[item]
 
 	if fItem {
-		if iItem.droppable {
+		if !iItem.undroppable {
 			room.items[item] = iItem
 			delete(inventory, item)
 			fmt.Println("Dropped.")
+
+			if item == "a bed" {
+				spawn = coords
+				fmt.Println("You will wake up here if you die.")
+			}
 		} else {
-			fmt.Println("You can't drop that.")
+			fail("You can't drop that.")
 		}
 	} else {
-		fmt.Printf("You don't have a %s.\n", item)
+		failf("You don't have a %s.\n", item)
 	}
 
-	roomMap[coords.x][coords.y][coords.z] = room
+	world.setRoom(coords, room)
 }
 
 func cbreakComm(item string, tool string) {
 	if item == "" {
-		fmt.Println("Break what?")
+		fail("Break what?")
+		return
+	}
+
+	if dir, ok := wallDirection(item); ok {
+		breakWall(dir)
 		return
 	}
 
@@ -1285,24 +2012,45 @@ func cbreakComm(item string, tool string) {
 	var fTool bool
 
 	if tool != "" {
+		if name, ok := findItem(inventory, tool); ok {
+			tool = name
+		}
+
 		iTool, fTool = inventory[tool]
 
 		if !fTool {
-			fmt.Printf("You're not carrying a %s.\n", tool)
+			failf("You're not carrying a %s.\n", tool)
 			return
 		}
 	}
 
 	coords := getRoom(x, y, z, false)
-	room := roomMap[coords.x][coords.y][coords.z]
+	room := world.room(coords)
 
 	if item == "tree" || item == "trees" || item == "a tree" {
 		fmt.Println("The tree breaks into blocks of wood, which you pick up.")
 		inventory["some wood"] = items["some wood"]
 		return
+	} else if item == "grass" || item == "the grass" || item == "some grass" {
+		breakGrass(room)
+		return
 	} else if item == "self" || item == "myself" {
-		fmt.Println(color.Ize(color.Red, "You have died."))
-		running = false
+		die()
+		return
+	}
+
+	if i, ok := findEntity(room, item); ok {
+		if fTool {
+			fmt.Printf("You swing %s at the %s.\n", tool, room.entities[i].alias())
+		}
+
+		attackEntity(&room, i, iTool, fTool)
+		world.setRoom(coords, room)
+		return
+	}
+
+	if name, ok := findItem(room.items, item); ok {
+		item = name
 	}
 
 	iItem, fItem := room.items[item]
@@ -1310,35 +2058,120 @@ func cbreakComm(item string, tool string) {
 	if fItem {
 		if iItem.ore {
 			if !fTool {
-				fmt.Println("You need a tool to break this ore.")
+				fail("You need a tool to break this ore.")
 				return
 			}
 
 			if iTool.tool {
 				if iTool.toolLevel < iItem.toolLevel {
-					fmt.Printf("%s is not strong enough to break this ore.\n", tool)
+					failf("%s is not strong enough to break this ore.\n", tool)
 				} else if iTool.toolType != iItem.toolType {
-					fmt.Println("You need a different kind of tool to break this ore.")
+					fail("You need a different kind of tool to break this ore.")
 				} else {
-					fmt.Printf("The ore breaks, dropping %s, which you pick up.", item)
+					fmt.Printf("You break the ore with %s, dropping %s, which you pick up.\n", tool, item)
+					actionTurns = digTurns(iTool, false)
 					inventory[item] = items[item]
 					if !iItem.infinite {
 						delete(room.items, item)
 					}
 				}
 			} else {
-				fmt.Printf("You can't break %s with %s.\n", item, tool)
+				failf("You can't break %s with %s.\n", item, tool)
 			}
-		} else if iItem.creature {
-			//https://github.com/dan200/ComputerCraft/blob/master/src/main/resources/assets/computercraft/lua/rom/programs/fun/adventure.lua#L1007
 		}
+	} else {
+		failf("You don't see a %s here.\n", item)
 	}
+
+	world.setRoom(coords, room)
 }
 
 func randomChoice[T any](t []T) T {
 	return t[rand.Intn(len(t))]
 }
 
+func simulate() {
+	if actionTurns == 0 {
+		actionTurns = 1
+		return
+	}
+
+	turns := actionTurns
+	actionTurns = 1
+	before := health
+
+	for i := 0; i < turns && running; i++ {
+		if health < before {
+			fmt.Println("You are interrupted.")
+			break
+		}
+
+		tick()
+	}
+
+	if running && health < maxHealth {
+		fmt.Println(color.Ize(color.Red, "You are injured."))
+	}
+}
+
+func tick() {
+	newMonstersHere := spawnMonsters()
+	wanderAnimals()
+	followPlayer()
+
+	if len(pets) > 0 {
+		petsFight()
+	}
+
+	if !respawning() {
+		lightFuses()
+
+		if running {
+			skeletonsShoot()
+		}
+
+		if running && timeInRoom >= 2 && !newMonstersHere {
+			monsterAttacks()
+		}
+	}
+
+	if !running {
+		return
+	}
+
+	turn += 1
+	timeInRoom += 1
+	updateFurnace()
+	burnTorches()
+}
+
 func main() {
-	fmt.Println(color.Ize(color.Red, "This is red"))
+	flag.BoolVar(&hardcore, "hardcore", false, "delete the world when you die")
+	flag.IntVar(&torchTurns, "torchturns", torchTurns, "how many turns a placed torch burns for")
+	flag.Parse()
+
+	if err := world.loadState(); err != nil {
+		fmt.Println(color.Ize(color.Red, fmt.Sprintf("Couldn't load your game: %v", err)))
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	lookComm([]string{})
+
+	for running {
+		fmt.Print(color.Ize(color.Yellow, "? "))
+
+		if !scanner.Scan() {
+			break
+		}
+
+		doCommand(strings.ToLower(strings.TrimSpace(scanner.Text())))
+
+		if running {
+			simulate()
+		}
+	}
+
+	if err := world.save(); err != nil {
+		fmt.Println(color.Ize(color.Red, fmt.Sprintf("Couldn't save the world: %v", err)))
+	}
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+)
+
+// newGame puts the player at the origin of a fresh world at the start of
+// the first day, in an empty room with no way out, carrying nothing but no
+// tea.
+func newGame(t *testing.T) RoomCoord {
+	world = newWorld(t.TempDir())
+	x, y, z = 0, 0, 0
+	turn = 0
+	timeInRoom = 0
+	actionTurns = 1
+	health = maxHealth
+	running = true
+	spawn = RoomCoord{}
+	hardcore = false
+	graceUntil = 0
+	inventory = map[string]Item{"no tea": items["no tea"]}
+	pets = []Entity{}
+	equipment = map[string]Item{}
+	held = ""
+	bank = ""
+
+	coords := RoomCoord{}
+	world.setRoom(coords, Room{items: map[string]Item{}, valid: true})
+	return coords
+}
+
+func give(names ...string) {
+	for _, name := range names {
+		inventory[name] = items[name]
+	}
+}
+
+func put(coords RoomCoord, names ...string) {
+	room := world.room(coords)
+
+	for _, name := range names {
+		room.items[name] = items[name]
+	}
+
+	world.setRoom(coords, room)
+}
+
+func TestItemizeStr(t *testing.T) {
+	tests := []struct {
+		items []string
+		want  string
+	}{
+		{nil, "nothing"},
+		{[]string{"some wood"}, "some wood"},
+		{[]string{"some wood", "some stone"}, "some wood and some stone"},
+		{[]string{"some wood", "some stone", "a torch"}, "some wood, some stone and a torch"},
+	}
+
+	for _, tt := range tests {
+		if got := itemizeStr(tt.items); got != tt.want {
+			t.Errorf("itemizeStr(%q) = %q, want %q", tt.items, got, tt.want)
+		}
+	}
+}
+
+func TestFindItem(t *testing.T) {
+	name, ok := findItem(items, "porkchops")
+
+	if !ok || name != "some pork" {
+		t.Errorf("findItem(items, %q) = %q, %v, want %q", "porkchops", name, ok, "some pork")
+	}
+
+	if _, ok := findItem(items, "unobtainium"); ok {
+		t.Errorf("findItem(items, %q) found something", "unobtainium")
+	}
+}
+
+func TestEat(t *testing.T) {
+	newGame(t)
+	health = 3
+	give("some cooked pork")
+
+	commands["eat"]([]string{"cooked pork"})
+
+	if want := 3 + items["some cooked pork"].heal; health != want {
+		t.Errorf("health after eating cooked pork = %d, want %d", health, want)
+	}
+
+	if _, ok := inventory["some cooked pork"]; ok {
+		t.Error("cooked pork still in the inventory after eating it")
+	}
+
+	health = maxHealth - 1
+	give("some cooked pork")
+	commands["eat"]([]string{"cooked pork"})
+
+	if health != maxHealth {
+		t.Errorf("health after eating at %d = %d, want %d", maxHealth-1, health, maxHealth)
+	}
+}
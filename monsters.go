@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	spawnRange = 1
+	spawnOdds  = 10
+)
+
+func spawnMonsters() bool {
+	newHere := false
+
+	for sx := -spawnRange; sx <= spawnRange; sx++ {
+		for sy := -1; sy <= 1; sy++ {
+			for sz := -spawnRange; sz <= spawnRange; sz++ {
+				h := y + sy
+
+				if h < -3 || h > 0 {
+					continue
+				}
+
+				coords := getRoom(x+sx, h, z+sz, false)
+				room := world.room(coords)
+				here := sx == 0 && sy == 0 && sz == 0
+				dark := isDark(coords)
+
+				if countMonsters(room) < maxMonsters && !isShelter(coords) && lightLevel(coords) < spawnLight && rand.Intn(spawnOdds) == 0 {
+					monster := randomChoice(monsters)
+					room.addEntity(newEntity(monster))
+
+					if here && !dark {
+						fmt.Printf("From the shadows, %s appears.\n", monster)
+						newHere = true
+					}
+				}
+
+				if h == 0 && isSunny() {
+					kept := []Entity{}
+
+					for _, e := range room.entities {
+						if !e.kind().nocturnal {
+							kept = append(kept, e)
+						} else if here && !dark {
+							fmt.Printf("With the light of the newly risen sun, %s burns to dust.\n", e.species)
+						}
+					}
+
+					room.entities = kept
+				}
+
+				world.setRoom(coords, room)
+			}
+		}
+	}
+
+	return newHere
+}
+
+func monsterAttacks() {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	for _, e := range room.entities {
+		if !e.hostile || e.species == "a creeper" {
+			continue
+		}
+
+		if rand.Intn(4) != 0 || (y == 0 && isSunny() && e.species == "a spider") {
+			continue
+		}
+
+		if isDark(coords) {
+			fmt.Println("A monster attacks you.")
+		} else {
+			fmt.Printf("The %s attacks you.\n", e.alias())
+		}
+
+		attacked(e.kind().damage)
+		return
+	}
+}
+
+// Creepers don't attack, they light their fuse once the player has lingered
+// in the room for a couple of turns, hiss, and blow up the turn after.
+func lightFuses() {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	for i := range room.entities {
+		e := &room.entities[i]
+
+		if e.species != "a creeper" {
+			continue
+		}
+
+		if timeInRoom < 2 {
+			e.fuse = 0
+			continue
+		}
+
+		e.fuse += 1
+
+		if e.fuse < 2 {
+			if isDark(coords) {
+				fmt.Println("Something hisses in the dark.")
+			} else {
+				fmt.Println("The creeper hisses.")
+			}
+
+			continue
+		}
+
+		explode(coords, room, i)
+		return
+	}
+
+	world.setRoom(coords, room)
+}
+
+func explode(coords RoomCoord, room Room, i int) {
+	creeper := room.removeEntity(i)
+
+	if isDark(coords) {
+		fmt.Println("Something explodes!")
+	} else {
+		fmt.Println("The creeper explodes!")
+	}
+
+	destroyed := []string{}
+
+	for name, item := range room.items {
+		if !item.heavy && !item.ore {
+			delete(room.items, name)
+			destroyed = append(destroyed, name)
+		}
+	}
+
+	if len(destroyed) > 0 {
+		fmt.Printf("The blast destroys %s.\n", itemizeStr(destroyed))
+	}
+
+	if _, ok := room.items["a chest"]; !ok {
+		room.chest = nil
+	}
+
+	if _, ok := room.items["a furnace"]; !ok {
+		room.furnace = Smelt{}
+	}
+
+	if _, ok := room.items["a bed"]; !ok && spawn == coords {
+		spawn = RoomCoord{}
+	}
+
+	world.setRoom(coords, room)
+	dark := isDark(coords)
+
+	kept := []Entity{}
+
+	for _, e := range room.entities {
+		e.health -= creeper.kind().damage
+
+		if e.health > 0 {
+			kept = append(kept, e)
+		} else if !dark {
+			fmt.Printf("The blast kills the %s.\n", e.alias())
+		}
+	}
+
+	room.entities = kept
+
+	if coords.y < 0 {
+		opened := []string{}
+
+		for _, dir := range []string{"north", "south", "east", "west", "down"} {
+			if room.exits.getExit(dir) || (dir == "down" && coords.y <= -3) || rand.Intn(2) != 0 {
+				continue
+			}
+
+			room.exits.setExit(dir, true)
+			dx, dy, dz := dirOffset(dir)
+			adjCoords := getRoom(coords.x+dx, coords.y+dy, coords.z+dz, false)
+			adj := world.room(adjCoords)
+			adj.exits.setExit(opposite(dir), true)
+			world.setRoom(adjCoords, adj)
+			opened = append(opened, dir)
+		}
+
+		if len(opened) > 0 {
+			fmt.Printf("The blast tears open a passage %s.\n", itemizeStr(opened))
+		}
+	}
+
+	world.setRoom(coords, room)
+	attacked(creeper.kind().damage)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCreeperFuse(t *testing.T) {
+	here := newGame(t)
+	addEntities(here, "a creeper")
+
+	timeInRoom = 1
+	lightFuses()
+	timeInRoom = 2
+	lightFuses()
+
+	if health != maxHealth || len(world.room(here).entities) != 1 {
+		t.Fatal("the creeper blew up on its first hiss")
+	}
+
+	lightFuses()
+
+	if health >= maxHealth {
+		t.Error("the player wasn't hurt by the blast")
+	}
+
+	if len(world.room(here).entities) != 0 {
+		t.Error("the creeper survived its own blast")
+	}
+}
+
+func TestCreeperResetsFuse(t *testing.T) {
+	here := newGame(t)
+	addEntities(here, "a creeper")
+
+	timeInRoom = 2
+	lightFuses()
+	timeInRoom = 0
+	lightFuses()
+	timeInRoom = 2
+	lightFuses()
+
+	if health != maxHealth {
+		t.Error("the creeper blew up although the player stepped away")
+	}
+}
+
+func TestExplode(t *testing.T) {
+	here := newGame(t)
+	put(here, "a bed", "a chest", "some coal", "a furnace")
+	room := world.room(here)
+	room.chest = map[string]Item{"a stone sword": items["a stone sword"]}
+	world.setRoom(here, room)
+	spawn = here
+	addEntities(here, "a creeper")
+
+	explode(here, world.room(here), 0)
+	room = world.room(here)
+
+	for _, name := range []string{"a bed", "a chest", "a furnace"} {
+		if _, ok := room.items[name]; ok {
+			t.Errorf("%s survived the blast", name)
+		}
+	}
+
+	for _, name := range []string{"some coal"} {
+		if _, ok := room.items[name]; !ok {
+			t.Errorf("%s was destroyed by the blast", name)
+		}
+	}
+
+	if room.chest != nil {
+		t.Error("the chest's contents outlived the chest")
+	}
+
+	if spawn != (RoomCoord{}) {
+		t.Errorf("spawn after the bed blew up = %v, want the origin", spawn)
+	}
+}
+
+func TestExplodeUnderground(t *testing.T) {
+	newGame(t)
+	y = -1
+	cave := RoomCoord{y: -1}
+
+	for i := 0; i < 20; i++ {
+		world.setRoom(cave, Room{items: map[string]Item{}, valid: true})
+		addEntities(cave, "a creeper")
+		health = maxHealth
+		explode(cave, world.room(cave), 0)
+
+		for _, dir := range world.room(cave).getExits() {
+			dx, dy, dz := dirOffset(dir)
+			adj := world.room(RoomCoord{x: dx, y: -1 + dy, z: dz})
+
+			if !adj.exits.getExit(opposite(dir)) {
+				t.Fatalf("the blast opened %s, but not the way back", dir)
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TwiN/go-color"
+)
+
+func stationName(station Station) string {
+	switch station {
+	case CraftingTable:
+		return "at a crafting table"
+	case Furnace:
+		return "in a furnace"
+	default:
+		return "by hand"
+	}
+}
+
+func toolFor(toolType ToolType, toolLevel int) string {
+	for name, item := range items {
+		if item.tool && item.toolType == toolType && item.toolLevel == toolLevel {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func gatherHint(name string) string {
+	item := items[name]
+
+	if item.ore {
+		if tool := toolFor(item.toolType, item.toolLevel); tool != "" {
+			return fmt.Sprintf("mine it with %s or better", tool)
+		}
+	}
+
+	if name == "some wood" {
+		return "break a tree"
+	}
+
+	if name == "some seeds" {
+		return "break some grass in a forest or plain"
+	}
+
+	if name == "some wheat" {
+		return "plant seeds with a shovel and harvest them"
+	}
+
+	for creature, kind := range species {
+		for _, drop := range kind.drops {
+			if drop == name {
+				return "kill " + creature
+			}
+		}
+
+		for _, drop := range kind.hitDrops {
+			if drop == name {
+				return "hit " + creature
+			}
+		}
+	}
+
+	for _, c := range catches {
+		if c.item == name {
+			return "fish for it in a river"
+		}
+	}
+
+	if item.infinite {
+		return "pick it up where you find it"
+	}
+
+	for _, material := range groundMaterials {
+		if material == name {
+			return "dig it up with a shovel"
+		}
+	}
+
+	return ""
+}
+
+func recipesComm(_ []string) {
+	byStation := map[Station][]string{}
+
+	for name, recipe := range recipes {
+		byStation[recipe.station] = append(byStation[recipe.station], name)
+	}
+
+	for _, station := range []Station{Hand, CraftingTable, Furnace} {
+		names := byStation[station]
+		sort.Strings(names)
+		fmt.Printf("You can make %s %s.\n", itemizeStr(names), stationName(station))
+	}
+}
+
+func recipeComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		fmt.Println("A recipe for what?")
+		return
+	}
+
+	names := recipesFor(item)
+
+	if len(names) == 0 {
+		fmt.Printf("You don't know how to make %s.\n", item)
+		return
+	}
+
+	item, ok := pickRecipe(world.room(getRoom(x, y, z, false)), names)
+
+	if !ok {
+		fmt.Printf("Which do you mean: %s?\n", itemizeChoices(names))
+		return
+	}
+
+	printRecipe(item, 0, map[string]bool{})
+}
+
+func printRecipe(item string, depth int, seen map[string]bool) {
+	line := strings.Repeat("  ", depth) + item
+	recipe, craftable := recipes[item]
+
+	if craftable {
+		line += " (" + stationName(recipe.station) + ")"
+	} else if hint := gatherHint(item); hint != "" {
+		line += " - " + hint
+	}
+
+	if _, ok := inventory[item]; ok && depth > 0 {
+		fmt.Println(color.Ize(color.Green, line+" [you have this]"))
+		return
+	}
+
+	fmt.Println(line)
+
+	if !craftable || seen[item] {
+		return
+	}
+
+	seen[item] = true
+
+	for _, req := range recipe.ingredients {
+		printRecipe(req, depth+1, seen)
+	}
+
+	delete(seen, item)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// output runs f and returns what it printed.
+func output(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	f()
+	os.Stdout = stdout
+	w.Close()
+
+	out, err := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(out)
+}
+
+func TestToolFor(t *testing.T) {
+	tests := []struct {
+		ore  string
+		want string
+	}{
+		{"some stone", "a wooden pickaxe"},
+		{"some coal", "a wooden pickaxe"},
+		{"some iron", "a stone pickaxe"},
+		{"some diamond", "an iron pickaxe"},
+	}
+
+	for _, tt := range tests {
+		ore := items[tt.ore]
+
+		if got := toolFor(ore.toolType, ore.toolLevel); got != tt.want {
+			t.Errorf("toolFor(%s) = %q, want %q", tt.ore, got, tt.want)
+		}
+	}
+}
+
+func TestRecipeTree(t *testing.T) {
+	newGame(t)
+
+	want := strings.Join([]string{
+		"an iron pickaxe (at a crafting table)",
+		"  some iron ingots (in a furnace)",
+		"    some iron - mine it with a stone pickaxe or better",
+		"  some sticks (by hand)",
+		"    some planks (by hand)",
+		"      some wood - break a tree",
+		"",
+	}, "\n")
+
+	if got := output(t, func() { recipeComm([]string{"iron pickaxe"}) }); got != want {
+		t.Errorf("recipe for iron pickaxe:\n%s\nwant:\n%s", got, want)
+	}
+
+	give("some sticks")
+	got := output(t, func() { recipeComm([]string{"iron pickaxe"}) })
+
+	if !strings.Contains(got, "  some sticks (by hand) [you have this]") {
+		t.Errorf("recipe for iron pickaxe doesn't mark the sticks as held:\n%s", got)
+	}
+
+	if strings.Contains(got, "some planks") {
+		t.Errorf("recipe for iron pickaxe goes on past the held sticks:\n%s", got)
+	}
+}
+
+func TestRecipeSharedAlias(t *testing.T) {
+	newGame(t)
+
+	if got := output(t, func() { recipeComm([]string{"pickaxe"}) }); !strings.HasPrefix(got, "Which do you mean:") {
+		t.Errorf("recipe for pickaxe = %q, want a question", got)
+	}
+
+	give("some stone", "some sticks")
+	put(RoomCoord{}, "a crafting table")
+
+	if got := output(t, func() { recipeComm([]string{"pickaxe"}) }); !strings.HasPrefix(got, "a stone pickaxe") {
+		t.Errorf("recipe for pickaxe while holding stone and sticks = %q, want the stone pickaxe", got)
+	}
+
+	if got := output(t, func() { recipeComm([]string{"torch"}) }); !strings.HasPrefix(got, "some torches") {
+		t.Errorf("recipe for torch = %q, want the torches", got)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	swimTurns   = 3
+	swimLoad    = 6
+	drownDamage = 3
+	boatRange   = 4
+	bridgeTurns = 3
+)
+
+// bank is the side of the river the player is standing on, named by the
+// direction it lies in. It only means anything in a room with a river.
+var bank = ""
+
+func sides(flow string) []string {
+	if flow == "north" || flow == "south" {
+		return []string{"east", "west"}
+	}
+
+	return []string{"north", "south"}
+}
+
+func alongRiver(flow string, dir string) bool {
+	return dir == flow || dir == opposite(flow)
+}
+
+// riverFrom continues any river flowing into or out of a neighbouring room,
+// so that rivers run unbroken across the surface.
+func riverFrom(coords RoomCoord) (string, bool) {
+	for _, dir := range directions[:4] {
+		dx, _, dz := dirOffset(dir)
+		adjCoords := RoomCoord{x: coords.x + dx, y: coords.y, z: coords.z + dz}
+
+		if !world.hasRoom(adjCoords) {
+			continue
+		}
+
+		adj := world.room(adjCoords)
+
+		if adj.valid && adj.river != "" && alongRiver(adj.river, dir) {
+			return adj.river, true
+		}
+	}
+
+	return "", false
+}
+
+func currentBank(room Room) string {
+	for _, side := range sides(room.river) {
+		if side == bank {
+			return bank
+		}
+	}
+
+	bank = randomChoice(sides(room.river))
+	return bank
+}
+
+// arrive works out which bank the player ends up on after walking dir into
+// a room.
+func arrive(dir string) {
+	room := world.room(getRoom(x, y, z, false))
+
+	if room.river == "" {
+		bank = ""
+	} else if !alongRiver(room.river, dir) && dir != "up" && dir != "down" {
+		bank = opposite(dir)
+	} else {
+		currentBank(room)
+	}
+}
+
+// riverInWay is whether the river cuts across the way out of the room in
+// direction dir from the bank the player is on.
+func riverInWay(room Room, dir string) bool {
+	if room.river == "" || alongRiver(room.river, dir) || dir == "up" || dir == "down" {
+		return false
+	}
+
+	return currentBank(room) != dir
+}
+
+func crossRiver(room Room, dir string) bool {
+	if !riverInWay(room, dir) {
+		return true
+	}
+
+	if room.bridge {
+		fmt.Println("You walk across the bridge.")
+		return true
+	}
+
+	if _, ok := inventory["a boat"]; ok {
+		fmt.Println("You paddle across the river in your boat.")
+		return true
+	}
+
+	fail("The river is in the way. You could swim across, or build a bridge.")
+	return false
+}
+
+func swimComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if room.river == "" {
+		fail("There's nowhere to swim here.")
+		return
+	}
+
+	bank = opposite(currentBank(room))
+	actionTurns = swimTurns
+	load := len(inventory) + 2*len(equipment)
+
+	if load > swimLoad && rand.Intn(10) < load-swimLoad {
+		fmt.Println("The weight of everything you're carrying drags you under. You come up coughing on the far bank.")
+		hurt(drownDamage)
+		return
+	}
+
+	fmt.Printf("You swim across the river to the %s bank.\n", bank)
+}
+
+func crossComm(vals []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if room.river == "" {
+		fail("There's no river here.")
+		return
+	}
+
+	if room.bridge {
+		bank = opposite(currentBank(room))
+		fmt.Printf("You walk across the bridge to the %s bank.\n", bank)
+		return
+	}
+
+	if _, ok := inventory["a boat"]; ok {
+		bank = opposite(currentBank(room))
+		fmt.Printf("You paddle across to the %s bank.\n", bank)
+		return
+	}
+
+	swimComm(vals)
+}
+
+func rowComm(_ []string) {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if room.river == "" {
+		fail("There's no river here.")
+		return
+	}
+
+	if _, ok := inventory["a boat"]; !ok {
+		fail("You need a boat.")
+		return
+	}
+
+	flow := room.river
+	travelled := 0
+
+	for travelled < boatRange && room.river == flow && room.exits.getExit(flow) {
+		dx, _, dz := dirOffset(flow)
+		x += dx
+		z += dz
+		travelled += 1
+		room = world.room(getRoom(x, y, z, false))
+	}
+
+	if travelled == 0 {
+		fail("Something blocks the river downstream.")
+		return
+	}
+
+	fmt.Printf("You let the current carry your boat %s.\n", flow)
+	currentBank(room)
+	timeInRoom = 0
+	world.unloadDistant(RoomCoord{x: x, y: y, z: z})
+	lookComm([]string{})
+}
+
+func buildComm(vals []string) {
+	var item string
+	var material string
+
+	if len(vals) == 0 {
+		item = ""
+		material = ""
+	} else if len(vals) == 1 {
+		item = vals[0]
+		material = ""
+	} else {
+		item = vals[0]
+		material = vals[1]
+	}
+
+	if item == "" {
+		fail("Build what?")
+		return
+	}
+
+	if item != "bridge" && item != "a bridge" {
+		failf("You don't know how to build %s.\n", item)
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if room.river == "" {
+		fail("There's no river here to bridge.")
+		return
+	}
+
+	if room.bridge {
+		fail("There's already a bridge here.")
+		return
+	}
+
+	if material == "" {
+		material = "some planks"
+	} else if name, ok := findItem(items, material); ok {
+		material = name
+	}
+
+	if material != "some planks" && material != "some stone" {
+		failf("You can't build a bridge out of %s.\n", material)
+		return
+	}
+
+	if _, ok := inventory[material]; !ok {
+		failf("You need %s to build a bridge.\n", material)
+		return
+	}
+
+	delete(inventory, material)
+	room.bridge = true
+	actionTurns = bridgeTurns
+	world.setRoom(coords, room)
+	fmt.Printf("You build a bridge of %s across the river.\n", items[material].aliases[0])
+}
+
+func describeRiver(room Room) {
+	if room.river == "" {
+		return
+	}
+
+	fmt.Printf("The river flows %s. You are on the %s bank.\n", room.river, currentBank(room))
+
+	if room.bridge {
+		fmt.Println("A bridge spans the river.")
+	}
+}
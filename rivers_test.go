@@ -0,0 +1,130 @@
+package main
+
+import (
+	"testing"
+)
+
+// riverRoom puts the player on the west bank of a river flowing north, with
+// open ways out on every side.
+func riverRoom(t *testing.T) RoomCoord {
+	here := newGame(t)
+	emptyRooms()
+
+	for _, dir := range directions[:4] {
+		connect(here, dir)
+	}
+
+	room := world.room(here)
+	room.river = "north"
+	room.items["a river"] = items["a river"]
+	world.setRoom(here, room)
+	bank = "west"
+	return here
+}
+
+func TestRiverInWay(t *testing.T) {
+	here := riverRoom(t)
+	room := world.room(here)
+
+	for dir, want := range map[string]bool{"north": false, "south": false, "west": false, "east": true, "up": false} {
+		if got := riverInWay(room, dir); got != want {
+			t.Errorf("riverInWay(%s) from the west bank = %v, want %v", dir, got, want)
+		}
+	}
+}
+
+func TestCrossRiver(t *testing.T) {
+	riverRoom(t)
+
+	walk("east")
+
+	if x != 0 {
+		t.Fatal("walked east across the river")
+	}
+
+	swimComm([]string{})
+
+	if bank != "east" || actionTurns != swimTurns {
+		t.Fatalf("swimming took the player to the %s bank in %d turns", bank, actionTurns)
+	}
+
+	walk("east")
+
+	if x != -1 {
+		t.Error("couldn't walk east from the east bank")
+	}
+}
+
+func TestBridge(t *testing.T) {
+	riverRoom(t)
+	give("some stone")
+
+	buildComm([]string{"bridge"})
+
+	if world.room(RoomCoord{}).bridge {
+		t.Fatal("built a bridge without planks")
+	}
+
+	buildComm([]string{"bridge", "stone"})
+
+	if !world.room(RoomCoord{}).bridge {
+		t.Fatal("couldn't build a bridge of stone")
+	}
+
+	walk("east")
+
+	if x != -1 {
+		t.Error("couldn't walk across the bridge")
+	}
+}
+
+func TestDigAcrossRiver(t *testing.T) {
+	here := riverRoom(t)
+	room := world.room(here)
+	room.exits.setExit("east", false)
+	world.setRoom(here, room)
+	give("a stone pickaxe", "a wooden shovel")
+
+	commands["dig"]([]string{"east"})
+
+	if x != 0 || world.room(here).exits.getExit("east") {
+		t.Error("dug across the river")
+	}
+}
+
+func TestRow(t *testing.T) {
+	here := riverRoom(t)
+	north := RoomCoord{z: 1}
+	connect(north, "north")
+	give("a boat")
+
+	for _, coords := range []RoomCoord{north, {z: 2}} {
+		room := world.room(coords)
+		room.river = "north"
+		world.setRoom(coords, room)
+	}
+
+	rowComm([]string{})
+
+	if x != 0 || z != 2 {
+		t.Errorf("rowed to %d, %d, want 0, 2", x, z)
+	}
+
+	if world.room(here).river != "north" {
+		t.Error("the river moved")
+	}
+}
+
+func TestRiverFrom(t *testing.T) {
+	newGame(t)
+	world.setRoom(RoomCoord{z: 1}, Room{items: map[string]Item{}, valid: true, river: "north"})
+	world.setRoom(RoomCoord{x: 1}, Room{items: map[string]Item{}, valid: true, river: "north"})
+
+	if flow, ok := riverFrom(RoomCoord{z: 2}); !ok || flow != "north" {
+		t.Errorf("riverFrom below a river flowing north = %q, %v, want it to carry on", flow, ok)
+	}
+
+	if flow, ok := riverFrom(RoomCoord{x: 2}); ok {
+		t.Errorf("riverFrom beside a river flowing north = %q, want no river", flow)
+	}
+}
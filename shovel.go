@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+)
+
+var (
+	groundMaterials = []string{
+		"some dirt", "some sand", "some gravel", "some snow",
+	}
+)
+
+func groundMaterial(biome int) string {
+	switch {
+	case hasSand(biome):
+		return "some sand"
+	case biome == 6:
+		return "some snow"
+	case hasStone(biome):
+		return "some gravel"
+	default:
+		return "some dirt"
+	}
+}
+
+func isGround(name string) bool {
+	if name == "ground" {
+		return true
+	}
+
+	if found, ok := findItem(items, name); ok {
+		for _, material := range groundMaterials {
+			if found == material {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// digTurns is how long it takes to dig or mine with a tool. Even a diamond
+// pickaxe is slower than walking down an open passage, but a shovel gets
+// through loose ground in half the time.
+func digTurns(iTool Item, loose bool) int {
+	turns := 6 - iTool.toolLevel
+
+	if loose && iTool.toolType == Shovel {
+		turns /= 2
+	}
+
+	if turns < 1 {
+		turns = 1
+	}
+
+	return turns
+}
+
+func digTool(loose bool) (string, bool) {
+	if name, ok := holding(); ok {
+		iItem := inventory[name]
+
+		if iItem.tool && (iItem.toolType == Pick || (loose && iItem.toolType == Shovel)) {
+			return name, true
+		}
+	}
+
+	if loose {
+		if name, ok := bestTool(Shovel, 0); ok {
+			return name, true
+		}
+	}
+
+	return bestTool(Pick, 0)
+}
+
+func digGround(room Room, what string, tool string) {
+	if y != 0 {
+		fail("There's nothing but stone down here.")
+		return
+	}
+
+	material := groundMaterial(room.biome)
+
+	if found, ok := findItem(items, what); ok && found != material {
+		failf("There's no %s here.\n", items[found].aliases[0])
+		return
+	}
+
+	if tool == "" {
+		tool, _ = pickTool(Shovel)
+	}
+
+	iTool, ok := inventory[tool]
+
+	if tool != "" && !ok {
+		failf("You're not carrying a %s.\n", tool)
+		return
+	}
+
+	if !iTool.tool || iTool.toolType != Shovel {
+		fail("You need a shovel to dig up the ground.")
+		return
+	}
+
+	inventory[material] = items[material]
+	actionTurns = digTurns(iTool, true)
+	fmt.Printf("You dig up %s with %s.\n", material, tool)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDigTurns(t *testing.T) {
+	tests := []struct {
+		tool  string
+		loose bool
+		want  int
+	}{
+		{"", false, 6},
+		{"", true, 6},
+		{"a wooden pickaxe", false, 5},
+		{"a stone pickaxe", false, 4},
+		{"an iron pickaxe", false, 3},
+		{"a diamond pickaxe", false, 2},
+		{"a diamond pickaxe", true, 2},
+		{"a wooden shovel", false, 5},
+		{"a wooden shovel", true, 2},
+		{"an iron shovel", true, 1},
+		{"a diamond shovel", true, 1},
+	}
+
+	for _, tt := range tests {
+		if got := digTurns(items[tt.tool], tt.loose); got != tt.want {
+			t.Errorf("digTurns(%q, %v) = %d, want %d", tt.tool, tt.loose, got, tt.want)
+		}
+	}
+}
+
+func TestGroundMaterial(t *testing.T) {
+	tests := map[int]string{
+		0: "some dirt",
+		3: "some gravel",
+		4: "some sand",
+		5: "some dirt",
+		6: "some snow",
+	}
+
+	for biome, want := range tests {
+		if got := groundMaterial(biome); got != want {
+			t.Errorf("groundMaterial(%s) = %q, want %q", biomes[biome], got, want)
+		}
+	}
+}
+
+func TestDigGround(t *testing.T) {
+	here := newGame(t)
+	room := world.room(here)
+	room.biome = 4
+
+	digGround(room, "ground", "")
+
+	if _, ok := inventory["some sand"]; ok || actionTurns != 0 {
+		t.Fatal("dug up sand without a shovel")
+	}
+
+	give("a stone pickaxe", "a wooden shovel")
+	holdComm([]string{"stone pickaxe"})
+	digGround(room, "dirt", "")
+
+	if _, ok := inventory["some dirt"]; ok {
+		t.Fatal("dug up dirt in the desert")
+	}
+
+	digGround(room, "sand", "")
+
+	if _, ok := inventory["some sand"]; !ok {
+		t.Fatal("couldn't dig up sand with a shovel in the desert")
+	}
+
+	if want := digTurns(items["a wooden shovel"], true); actionTurns != want {
+		t.Errorf("digging sand with a wooden shovel took %d turns, want %d", actionTurns, want)
+	}
+
+	y = -1
+	delete(inventory, "some sand")
+	digGround(room, "ground", "")
+
+	if _, ok := inventory["some sand"]; ok {
+		t.Error("dug up the surface from underground")
+	}
+}
+
+func TestDigTool(t *testing.T) {
+	newGame(t)
+	give("a stone pickaxe", "a wooden shovel")
+
+	if name, _ := digTool(true); name != "a wooden shovel" {
+		t.Errorf("digTool(loose) = %q, want the shovel", name)
+	}
+
+	if name, _ := digTool(false); name != "a stone pickaxe" {
+		t.Errorf("digTool(stone) = %q, want the pickaxe", name)
+	}
+
+	holdComm([]string{"stone pickaxe"})
+
+	if name, _ := digTool(true); name != "a stone pickaxe" {
+		t.Errorf("digTool(loose) holding a pickaxe = %q, want the pickaxe", name)
+	}
+}
+
+func TestDigDown(t *testing.T) {
+	here := newGame(t)
+	room := world.room(here)
+	room.biome = 0
+	world.setRoom(here, room)
+	give("a wooden shovel")
+
+	// Light the way, so the player doesn't stumble into a hole below.
+	world.setRoom(RoomCoord{y: -1}, Room{items: map[string]Item{}, valid: true})
+	placeTorch(RoomCoord{y: -1}, torchTurns)
+
+	commands["dig"]([]string{"down"})
+
+	if y != -1 {
+		t.Fatalf("after digging down the player is at y = %d, want -1", y)
+	}
+
+	if !world.room(here).exits.getExit("down") || !world.room(RoomCoord{y: -1}).exits.getExit("up") {
+		t.Error("digging down didn't open the way between the rooms")
+	}
+}
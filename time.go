@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var (
+	// freeActions only tell the player about the world, so the world doesn't
+	// move on while they do them.
+	freeActions = map[string]bool{
+		"noinput":   true,
+		"badinput":  true,
+		"help":      true,
+		"look":      true,
+		"inventory": true,
+		"equipment": true,
+		"recipes":   true,
+		"recipe":    true,
+		"chest":     true,
+		"hint":      true,
+	}
+	timesOfDay = map[string]func(int) bool{
+		"day":     func(tod int) bool { return tod < 9 },
+		"morning": func(tod int) bool { return tod == 15 || tod < 4 },
+		"dusk":    func(tod int) bool { return tod == 9 },
+		"sunset":  func(tod int) bool { return tod == 9 },
+		"evening": func(tod int) bool { return tod == 9 },
+		"night":   func(tod int) bool { return tod >= 10 && tod < 15 },
+		"dawn":    func(tod int) bool { return tod == 15 },
+		"sunrise": func(tod int) bool { return tod == 15 },
+	}
+)
+
+// fail tells the player why they couldn't do something. Only actions that
+// actually happen take time, so failing is free.
+func fail(a ...any) {
+	actionTurns = 0
+	fmt.Println(a...)
+}
+
+func failf(format string, a ...any) {
+	actionTurns = 0
+	fmt.Printf(format, a...)
+}
+
+func maxWait() int {
+	return len(dayCycle) * 3
+}
+
+func turnsUntil(isTime func(int) bool) int {
+	for t := turn + 1; t <= turn+maxWait(); t++ {
+		if isTime(int(timeOfDayAt(t))) {
+			return t - turn
+		}
+	}
+
+	return maxWait()
+}
+
+func waitComm(vals []string) {
+	var count string
+
+	if len(vals) == 0 {
+		count = ""
+	} else {
+		count = vals[0]
+	}
+
+	if count != "" {
+		n, err := strconv.Atoi(count)
+
+		if err != nil || n < 1 {
+			fail("Wait how long?")
+			return
+		}
+
+		if n > maxWait() {
+			failf("You can't wait more than %d turns at once.\n", maxWait())
+			return
+		}
+
+		actionTurns = n
+	}
+
+	fmt.Println("Time passes...")
+}
+
+func waitUntilComm(vals []string) {
+	var until string
+
+	if len(vals) == 0 {
+		until = ""
+	} else {
+		until = vals[0]
+	}
+
+	isTime, ok := timesOfDay[until]
+
+	if !ok {
+		failf("I don't know when %s is.\n", until)
+		return
+	}
+
+	if isTime(int(getTimeOfDay())) {
+		failf("It's %s already.\n", until)
+		return
+	}
+
+	actionTurns = turnsUntil(isTime)
+	fmt.Printf("You wait until %s...\n", until)
+}
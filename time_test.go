@@ -0,0 +1,136 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTimesOfDay(t *testing.T) {
+	tests := []struct {
+		name string
+		tod  int
+		want bool
+	}{
+		{"day", 1, true},
+		{"day", 8, true},
+		{"day", 9, false},
+		{"day", 15, false},
+		{"morning", 15, true},
+		{"morning", 1, true},
+		{"morning", 3, true},
+		{"morning", 4, false},
+		{"morning", 12, false},
+		{"dusk", 9, true},
+		{"sunset", 9, true},
+		{"evening", 8, false},
+		{"night", 9, false},
+		{"night", 10, true},
+		{"night", 14, true},
+		{"night", 15, false},
+		{"dawn", 15, true},
+		{"sunrise", 1, false},
+	}
+
+	for _, tt := range tests {
+		if got := timesOfDay[tt.name](tt.tod); got != tt.want {
+			t.Errorf("%s at %d = %v, want %v", tt.name, tt.tod, got, tt.want)
+		}
+	}
+}
+
+// Every hour of the day is exactly one of day, dusk, night or dawn.
+func TestTimesOfDayCover(t *testing.T) {
+	for tod := 1; tod <= len(dayCycle); tod++ {
+		n := 0
+
+		for _, name := range []string{"day", "dusk", "night", "dawn"} {
+			if timesOfDay[name](tod) {
+				n++
+			}
+		}
+
+		if n != 1 {
+			t.Errorf("time of day %d matches %d periods, want 1", tod, n)
+		}
+	}
+}
+
+func TestTurnsUntil(t *testing.T) {
+	for name, isTime := range timesOfDay {
+		turn = 0
+		n := turnsUntil(isTime)
+
+		if n < 1 || n > maxWait() || !isTime(int(timeOfDayAt(n))) {
+			t.Errorf("turnsUntil(%s) = %d, which isn't %s", name, n, name)
+		}
+	}
+}
+
+// play runs a command the way the game loop does.
+func play(text string) {
+	doCommand(text)
+	simulate()
+}
+
+func TestActionTurns(t *testing.T) {
+	here := newGame(t)
+	put(here, "a bed")
+
+	tests := []struct {
+		command string
+		turns   int
+	}{
+		{"look", 0},
+		{"inventory", 0},
+		{"hint", 0},
+		{"take the unobtainium", 0},
+		{"take the bed", 1},
+		{"wait", 1},
+		{"wait 5", 5},
+		{"wait 1000", 0},
+		{"wait until tea time", 0},
+	}
+
+	for _, tt := range tests {
+		before := turn
+		play(tt.command)
+
+		if got := turn - before; got != tt.turns {
+			t.Errorf("%q took %d turns, want %d", tt.command, got, tt.turns)
+		}
+	}
+
+	dusk := timesOfDay["dusk"]
+	play("wait until dusk")
+
+	if !dusk(int(getTimeOfDay())) || dusk(int(timeOfDayAt(turn-1))) {
+		t.Errorf("waiting until dusk stopped at time of day %d", int(getTimeOfDay()))
+	}
+
+	before := turn
+	play("wait until dusk")
+
+	if turn != before {
+		t.Error("waiting until dusk at dusk took time")
+	}
+}
+
+// A long action stops as soon as something hurts the player, so they get a
+// chance to react.
+func TestActionInterrupted(t *testing.T) {
+	newGame(t)
+	y = -1
+	cave := RoomCoord{y: -1}
+	world.setRoom(cave, Room{items: map[string]Item{}, valid: true})
+	addEntities(cave, "a zombie")
+	timeInRoom = 2
+
+	play("wait 20")
+
+	if turn >= 20 {
+		t.Error("waited out the whole 20 turns with a zombie attacking")
+	}
+
+	if health >= maxHealth {
+		t.Error("the zombie never attacked")
+	}
+}
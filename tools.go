@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+)
+
+var held = ""
+
+func holding() (string, bool) {
+	if _, ok := inventory[held]; ok {
+		return held, true
+	}
+
+	return "", false
+}
+
+// pickTool works out what to use when the player doesn't name a tool: the
+// item in their hand if it suits the job, otherwise the best one they carry.
+func pickTool(toolType ToolType) (string, bool) {
+	if name, ok := holding(); ok {
+		if iItem := inventory[name]; iItem.tool && iItem.toolType == toolType {
+			return name, true
+		}
+	}
+
+	return bestTool(toolType, 0)
+}
+
+func holdComm(vals []string) {
+	var item string
+
+	if len(vals) == 0 {
+		item = ""
+	} else {
+		item = vals[0]
+	}
+
+	if item == "" {
+		if name, ok := holding(); ok {
+			fmt.Printf("You are holding %s.\n", name)
+		} else {
+			fmt.Println("Your hands are empty.")
+		}
+
+		return
+	}
+
+	if item == "nothing" {
+		held = ""
+		fmt.Println("You put away what you were holding.")
+		return
+	}
+
+	name, ok := findItem(inventory, item)
+
+	if !ok {
+		failf("You don't have a %s.\n", item)
+		return
+	}
+
+	held = name
+	fmt.Printf("You hold %s in your hand.\n", name)
+}
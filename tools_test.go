@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestBestTool(t *testing.T) {
+	newGame(t)
+	give("a wooden pickaxe", "a stone pickaxe", "a diamond sword")
+
+	if name, _ := bestTool(Pick, 0); name != "a stone pickaxe" {
+		t.Errorf("bestTool(Pick, 0) = %q, want the stone pickaxe", name)
+	}
+
+	if name, ok := bestTool(Pick, items["some diamond"].toolLevel); ok {
+		t.Errorf("bestTool(Pick) for diamond = %q, want none", name)
+	}
+
+	if _, ok := bestTool(Shovel, 0); ok {
+		t.Error("found a shovel in an inventory without one")
+	}
+}
+
+func TestPickTool(t *testing.T) {
+	newGame(t)
+	give("a wooden pickaxe", "a stone pickaxe", "a stone sword")
+
+	if name, _ := pickTool(Pick); name != "a stone pickaxe" {
+		t.Errorf("pickTool(Pick) with empty hands = %q, want the stone pickaxe", name)
+	}
+
+	holdComm([]string{"wooden pickaxe"})
+
+	if name, _ := pickTool(Pick); name != "a wooden pickaxe" {
+		t.Errorf("pickTool(Pick) holding the wooden pickaxe = %q, want it", name)
+	}
+
+	if name, _ := pickTool(Sword); name != "a stone sword" {
+		t.Errorf("pickTool(Sword) holding a pickaxe = %q, want the stone sword", name)
+	}
+
+	delete(inventory, "a wooden pickaxe")
+
+	if _, ok := holding(); ok {
+		t.Error("still holding the pickaxe after it left the inventory")
+	}
+}
+
+// Mining without naming a tool uses the best pickaxe the player has.
+func TestMineWithBestTool(t *testing.T) {
+	here := newGame(t)
+	put(here, "some iron")
+	give("a wooden pickaxe")
+
+	commands["mine"]([]string{"iron"})
+
+	if _, ok := inventory["some iron"]; ok {
+		t.Fatal("mined iron with a wooden pickaxe")
+	}
+
+	give("a stone pickaxe")
+	commands["mine"]([]string{"iron"})
+
+	if _, ok := inventory["some iron"]; !ok {
+		t.Error("didn't reach for the stone pickaxe to mine iron")
+	}
+}
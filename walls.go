@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	wallMaterials = []string{
+		"some stone", "some dirt", "some planks", "some wool",
+	}
+)
+
+func (e Exits) wall(s string) (string, bool) {
+	material, ok := e.walls[s]
+	return material, ok
+}
+
+func (e *Exits) setWall(s string, material string) {
+	if e.walls == nil {
+		e.walls = make(map[string]string)
+	}
+
+	e.walls[s] = material
+}
+
+func isWallMaterial(name string) bool {
+	for _, material := range wallMaterials {
+		if material == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitDirection pulls a trailing direction off a command argument, so
+// "stone north" becomes "stone" and "north".
+func splitDirection(s string) (string, string) {
+	for _, dir := range directions {
+		if s == dir {
+			return "", dir
+		}
+
+		if strings.HasSuffix(s, " "+dir) {
+			return strings.TrimSuffix(s, " "+dir), dir
+		}
+	}
+
+	return s, ""
+}
+
+// wallDirection picks out which wall the player means from things like
+// "wall north", "the wall to the north" and "north wall".
+func wallDirection(item string) (string, bool) {
+	item = strings.TrimPrefix(item, "the ")
+
+	if item == "wall" {
+		return "", true
+	}
+
+	if strings.HasPrefix(item, "wall ") {
+		dir := strings.TrimPrefix(item, "wall ")
+		dir = strings.TrimPrefix(dir, "to the ")
+		return dir, true
+	}
+
+	if strings.HasSuffix(item, " wall") {
+		return strings.TrimSuffix(item, " wall"), true
+	}
+
+	return "", false
+}
+
+func neighbour(dir string) RoomCoord {
+	dx, dy, dz := dirOffset(dir)
+	return getRoom(x+dx, y+dy, z+dz, false)
+}
+
+func placeWall(item string, dir string) {
+	name, ok := findItem(inventory, item)
+
+	if !ok {
+		failf("You don't have any %s.\n", item)
+		return
+	}
+
+	if !isWallMaterial(name) {
+		failf("You can't build a wall out of %s.\n", name)
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	if !room.exits.getExit(dir) {
+		failf("There's no way through to the %s to wall off.\n", dir)
+		return
+	}
+
+	if name, ok := room.exits.door(dir); ok {
+		failf("There's %s in the way.\n", name)
+		return
+	}
+
+	coords1 := neighbour(dir)
+	room1 := world.room(coords1)
+	room.exits.setWall(dir, name)
+	room.exits.setExit(dir, false)
+	room1.exits.setWall(opposite(dir), name)
+	room1.exits.setExit(opposite(dir), false)
+	world.setRoom(coords, room)
+	world.setRoom(coords1, room1)
+	delete(inventory, name)
+	fmt.Printf("You wall off the way %s with %s.\n", dir, name)
+}
+
+func breakWall(dir string) {
+	if dir == "" {
+		fail("Break the wall to which side?")
+		return
+	}
+
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+	material, ok := room.exits.wall(dir)
+
+	if !ok {
+		failf("There's no wall to the %s.\n", dir)
+		return
+	}
+
+	tool := ""
+
+	if items[material].ore {
+		tool, ok = pickTool(items[material].toolType)
+
+		if !ok {
+			failf("You need a %s to break a wall of %s.\n", toolTypeName(items[material].toolType), material)
+			return
+		}
+	}
+
+	coords1 := neighbour(dir)
+	room1 := world.room(coords1)
+	room.exits.setExit(dir, true)
+	room1.exits.setExit(opposite(dir), true)
+	world.setRoom(coords, room)
+	world.setRoom(coords1, room1)
+	inventory[material] = items[material]
+
+	if tool != "" {
+		fmt.Printf("You break down the wall with %s and take back %s.\n", tool, material)
+	} else {
+		fmt.Printf("You break down the wall and take back %s.\n", material)
+	}
+}
+
+func describeWalls(room Room) {
+	for _, dir := range directions {
+		if material, ok := room.exits.wall(dir); ok {
+			fmt.Printf("A wall of %s blocks the way %s.\n", items[material].aliases[0], dir)
+		}
+	}
+}
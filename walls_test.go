@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestWallDirection(t *testing.T) {
+	tests := []struct {
+		item string
+		dir  string
+		ok   bool
+	}{
+		{"wall", "", true},
+		{"wall north", "north", true},
+		{"the wall to the east", "east", true},
+		{"south wall", "south", true},
+		{"stone", "", false},
+	}
+
+	for _, tt := range tests {
+		if dir, ok := wallDirection(tt.item); dir != tt.dir || ok != tt.ok {
+			t.Errorf("wallDirection(%q) = %q, %v, want %q, %v", tt.item, dir, ok, tt.dir, tt.ok)
+		}
+	}
+
+	if item, dir := splitDirection("stone north"); item != "stone" || dir != "north" {
+		t.Errorf("splitDirection(%q) = %q, %q", "stone north", item, dir)
+	}
+}
+
+func TestPlaceAndBreakWall(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	north := connect(here, "north")
+	give("some planks")
+
+	placeWall("planks", "north")
+
+	if world.room(here).exits.getExit("north") || world.room(north).exits.getExit("south") {
+		t.Fatal("the way north is still open after walling it off")
+	}
+
+	if _, ok := inventory["some planks"]; ok {
+		t.Error("the planks are still in the inventory after building a wall")
+	}
+
+	if !isShelter(here) {
+		t.Error("walling off the only way out didn't make a shelter")
+	}
+
+	breakWall("north")
+
+	if !world.room(here).exits.getExit("north") || !world.room(north).exits.getExit("south") {
+		t.Error("the way north is still shut after breaking the wall")
+	}
+
+	if _, ok := inventory["some planks"]; !ok {
+		t.Error("didn't get the planks back from the wall")
+	}
+}
+
+func TestStoneWallNeedsPickaxe(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	connect(here, "north")
+	give("some stone")
+	placeWall("stone", "north")
+
+	breakWall("north")
+
+	if world.room(here).exits.getExit("north") {
+		t.Fatal("broke a stone wall with bare hands")
+	}
+
+	give("a wooden pickaxe")
+	breakWall("north")
+
+	if !world.room(here).exits.getExit("north") {
+		t.Error("couldn't break a stone wall with a pickaxe")
+	}
+}
+
+// Walling off a shaft keeps its ladder for when the wall comes down, even
+// across a save.
+func TestWallKeepsLadder(t *testing.T) {
+	here := newGame(t)
+	below := connect(here, "down")
+	give("a ladder", "some dirt")
+	placeLadder("ladder down")
+	placeWall("dirt", "down")
+
+	if err := world.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	world = newWorld(world.dir)
+
+	if material, ok := world.room(here).exits.wall("down"); !ok || material != "some dirt" {
+		t.Fatalf("wall down after reloading = %q, %v, want some dirt", material, ok)
+	}
+
+	breakWall("down")
+
+	if world.room(here).exits.passage("down") != Ladder || world.room(below).exits.passage("up") != Ladder {
+		t.Error("the ladder was lost behind the wall")
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	wanderOdds = 10
+	followOdds = 3
+)
+
+var (
+	directions = []string{
+		"north", "south", "east", "west", "up", "down",
+	}
+)
+
+func dirOffset(dir string) (int, int, int) {
+	switch dir {
+	case "north":
+		return 0, 0, 1
+	case "south":
+		return 0, 0, -1
+	case "east":
+		return -1, 0, 0
+	case "west":
+		return 1, 0, 0
+	case "up":
+		return 0, 1, 0
+	case "down":
+		return 0, -1, 0
+	default:
+		return 0, 0, 0
+	}
+}
+
+func opposite(dir string) string {
+	switch dir {
+	case "north":
+		return "south"
+	case "south":
+		return "north"
+	case "east":
+		return "west"
+	case "west":
+		return "east"
+	case "up":
+		return "down"
+	case "down":
+		return "up"
+	default:
+		return ""
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func arrivalText(e Entity, from string) string {
+	name := e.name()
+	verb := e.kind().verb
+
+	if verb == "" {
+		verb = "wanders"
+	}
+
+	if from == "up" {
+		return fmt.Sprintf("%s %s down from above.", capitalize(name), verb)
+	} else if from == "down" {
+		return fmt.Sprintf("%s %s up from below.", capitalize(name), verb)
+	}
+
+	return fmt.Sprintf("%s %s in from the %s.", capitalize(name), verb, from)
+}
+
+func addArrival(room *Room, text string) {
+	if room.arrived != turn {
+		room.arrivals = nil
+		room.arrived = turn
+	}
+
+	room.arrivals = append(room.arrivals, text)
+}
+
+func wanderAnimals() {
+	type move struct {
+		entity Entity
+		to     RoomCoord
+		from   string
+	}
+
+	moves := []move{}
+
+	for sx := -2; sx <= 2; sx++ {
+		for sz := -2; sz <= 2; sz++ {
+			coords := getRoom(x+sx, 0, z+sz, false)
+			room := world.room(coords)
+			kept := []Entity{}
+
+			for _, e := range room.entities {
+				exits := []string{}
+
+				for _, dir := range room.getExits() {
+					if dir != "up" && dir != "down" {
+						exits = append(exits, dir)
+					}
+				}
+
+				if e.hostile || len(exits) == 0 || rand.Intn(wanderOdds) != 0 {
+					kept = append(kept, e)
+					continue
+				}
+
+				dir := randomChoice(exits)
+				dx, _, dz := dirOffset(dir)
+				moves = append(moves, move{entity: e, to: RoomCoord{x: coords.x + dx, y: 0, z: coords.z + dz}, from: opposite(dir)})
+			}
+
+			if len(kept) != len(room.entities) {
+				room.entities = kept
+				world.setRoom(coords, room)
+			}
+		}
+	}
+
+	for _, m := range moves {
+		coords := getRoom(m.to.x, m.to.y, m.to.z, false)
+		room := world.room(coords)
+		room.addEntity(m.entity)
+		text := arrivalText(m.entity, m.from)
+		addArrival(&room, text)
+		world.setRoom(coords, room)
+
+		if coords == (RoomCoord{x: x, y: y, z: z}) && !isDark(coords) {
+			fmt.Println(text)
+		}
+	}
+}
+
+func followPlayer() {
+	coords := getRoom(x, y, z, false)
+	room := world.room(coords)
+
+	for _, dir := range directions {
+		dx, dy, dz := dirOffset(dir)
+		adjCoords := RoomCoord{x: x + dx, y: y + dy, z: z + dz}
+
+		if !room.exits.getExit(dir) || !world.hasRoom(adjCoords) {
+			continue
+		}
+
+		if _, ok := room.exits.door(dir); ok {
+			continue
+		}
+
+		adj := world.room(adjCoords)
+
+		if !adj.exits.getExit(opposite(dir)) {
+			continue
+		}
+
+		kept := []Entity{}
+
+		for _, e := range adj.entities {
+			if !e.hostile || countMonsters(room) >= maxMonsters || rand.Intn(followOdds) != 0 {
+				kept = append(kept, e)
+				continue
+			}
+
+			room.addEntity(e)
+			text := arrivalText(e, dir)
+			addArrival(&room, text)
+
+			if !isDark(coords) {
+				fmt.Println(text)
+			} else {
+				fmt.Println("You hear something moving in the dark.")
+			}
+		}
+
+		adj.entities = kept
+		world.setRoom(adjCoords, adj)
+	}
+
+	world.setRoom(coords, room)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+)
+
+// emptyRooms fills the surface around the player with empty rooms that have
+// no way out, so nothing turns up that a test didn't put there.
+func emptyRooms() {
+	for sx := -3; sx <= 3; sx++ {
+		for sz := -3; sz <= 3; sz++ {
+			world.setRoom(RoomCoord{x: sx, z: sz}, Room{items: map[string]Item{}, valid: true})
+		}
+	}
+}
+
+// connect opens the way between a room and the one next to it.
+func connect(coords RoomCoord, dir string) RoomCoord {
+	dx, dy, dz := dirOffset(dir)
+	next := RoomCoord{x: coords.x + dx, y: coords.y + dy, z: coords.z + dz}
+
+	if !world.hasRoom(next) {
+		world.setRoom(next, Room{items: map[string]Item{}, valid: true})
+	}
+
+	room := world.room(coords)
+	room.exits.setExit(dir, true)
+	world.setRoom(coords, room)
+
+	room = world.room(next)
+	room.exits.setExit(opposite(dir), true)
+	world.setRoom(next, room)
+	return next
+}
+
+func addEntities(coords RoomCoord, names ...string) {
+	room := world.room(coords)
+
+	for _, name := range names {
+		room.addEntity(newEntity(name))
+	}
+
+	world.setRoom(coords, room)
+}
+
+func TestWanderAnimals(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	north := connect(here, "north")
+	addEntities(here, "a cow", "a cow", "a zombie")
+
+	for i := 0; i < 200; i++ {
+		wanderAnimals()
+	}
+
+	cows := 0
+
+	for sx := -3; sx <= 3; sx++ {
+		for sz := -3; sz <= 3; sz++ {
+			coords := RoomCoord{x: sx, z: sz}
+
+			for _, e := range world.room(coords).entities {
+				if e.species == "a cow" {
+					cows += 1
+
+					if coords != here && coords != north {
+						t.Errorf("a cow wandered to %v, which has no way in", coords)
+					}
+				}
+			}
+		}
+	}
+
+	if cows != 2 {
+		t.Errorf("%d cows after wandering, want 2", cows)
+	}
+
+	if !hasMonster(world.room(here)) {
+		t.Error("the zombie wandered off instead of staying put")
+	}
+}
+
+func TestFollowPlayer(t *testing.T) {
+	here := newGame(t)
+	emptyRooms()
+	north := connect(here, "north")
+	south := connect(here, "south")
+	addEntities(north, "a zombie")
+	addEntities(south, "a zombie")
+
+	room := world.room(here)
+	room.exits.setDoor("south", "a door")
+	world.setRoom(here, room)
+
+	for i := 0; i < 100; i++ {
+		followPlayer()
+	}
+
+	if hasMonster(world.room(north)) {
+		t.Error("the zombie to the north never followed the player in")
+	}
+
+	if !hasMonster(world.room(south)) {
+		t.Error("the zombie to the south came through a closed door")
+	}
+}
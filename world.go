@@ -0,0 +1,466 @@
+package main
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/TwiN/go-color"
+)
+
+const (
+	worldDir    = "world"
+	chunkSize   = 8
+	chunkRadius = 2
+)
+
+type ChunkCoord struct {
+	x int
+	z int
+}
+
+func floorDiv(a int, b int) int {
+	q := a / b
+
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q -= 1
+	}
+
+	return q
+}
+
+func (c RoomCoord) chunk() ChunkCoord {
+	return ChunkCoord{x: floorDiv(c.x, chunkSize), z: floorDiv(c.z, chunkSize)}
+}
+
+type Chunk struct {
+	rooms map[RoomCoord]Room
+	dirty bool
+}
+
+// World keeps the rooms near the player in memory, grouped into chunks of
+// chunkSize by chunkSize columns. Chunks further than chunkRadius from the
+// player are written to dir and read back the next time they are needed.
+// The player's own state is saved alongside them, so that the turn count
+// the rooms were saved against carries on from where it left off.
+type World struct {
+	dir     string
+	chunks  map[ChunkCoord]*Chunk
+	deleted bool
+}
+
+func newWorld(dir string) *World {
+	return &World{
+		dir:    dir,
+		chunks: make(map[ChunkCoord]*Chunk),
+	}
+}
+
+func (w *World) chunk(c ChunkCoord) *Chunk {
+	chunk, ok := w.chunks[c]
+
+	if ok {
+		return chunk
+	}
+
+	chunk, err := w.loadChunk(c)
+
+	if err != nil {
+		fmt.Println(color.Ize(color.Red, fmt.Sprintf("Couldn't load chunk %d, %d: %v", c.x, c.z, err)))
+		chunk = &Chunk{rooms: make(map[RoomCoord]Room)}
+	}
+
+	w.chunks[c] = chunk
+	return chunk
+}
+
+func (w *World) hasRoom(c RoomCoord) bool {
+	_, ok := w.chunk(c.chunk()).rooms[c]
+	return ok
+}
+
+func (w *World) room(c RoomCoord) Room {
+	return w.chunk(c.chunk()).rooms[c]
+}
+
+func (w *World) setRoom(c RoomCoord, room Room) {
+	chunk := w.chunk(c.chunk())
+	chunk.rooms[c] = room
+	chunk.dirty = true
+}
+
+func (w *World) unloadDistant(c RoomCoord) {
+	center := c.chunk()
+
+	for coords, chunk := range w.chunks {
+		dx := coords.x - center.x
+		dz := coords.z - center.z
+
+		if dx >= -chunkRadius && dx <= chunkRadius && dz >= -chunkRadius && dz <= chunkRadius {
+			continue
+		}
+
+		if chunk.dirty {
+			if err := w.saveChunk(coords, chunk); err != nil {
+				// Keep the chunk in memory rather than lose it.
+				continue
+			}
+		}
+
+		delete(w.chunks, coords)
+	}
+}
+
+func (w *World) save() error {
+	if w.deleted {
+		return nil
+	}
+
+	for coords, chunk := range w.chunks {
+		if !chunk.dirty {
+			continue
+		}
+
+		if err := w.saveChunk(coords, chunk); err != nil {
+			return err
+		}
+	}
+
+	return w.saveState()
+}
+
+func (w *World) delete() error {
+	w.chunks = make(map[ChunkCoord]*Chunk)
+	w.deleted = true
+	return os.RemoveAll(w.dir)
+}
+
+func (w *World) chunkPath(c ChunkCoord) string {
+	return filepath.Join(w.dir, fmt.Sprintf("chunk.%d.%d.gob", c.x, c.z))
+}
+
+func (w *World) statePath() string {
+	return filepath.Join(w.dir, "state.gob")
+}
+
+type stateRecord struct {
+	Turn       int
+	X          int
+	Y          int
+	Z          int
+	Health     int
+	SpawnX     int
+	SpawnY     int
+	SpawnZ     int
+	Inventory  []itemRecord
+	Equipment  []itemRecord
+	Pets       []entityRecord
+	Held       string
+	Bank       string
+	TimeInRoom int
+	GraceUntil int
+}
+
+func (w *World) saveState() error {
+	record := stateRecord{
+		Turn:       turn,
+		X:          x,
+		Y:          y,
+		Z:          z,
+		Health:     health,
+		SpawnX:     spawn.x,
+		SpawnY:     spawn.y,
+		SpawnZ:     spawn.z,
+		Inventory:  itemRecords(inventory),
+		Equipment:  itemRecords(equipment),
+		Held:       held,
+		Bank:       bank,
+		TimeInRoom: timeInRoom,
+		GraceUntil: graceUntil,
+	}
+
+	for _, e := range pets {
+		record.Pets = append(record.Pets, newEntityRecord(e))
+	}
+
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(w.statePath())
+
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(file).Encode(record); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// loadState picks the game up where it was saved. A world without a saved
+// state is a new game, and everything keeps its starting value.
+func (w *World) loadState() error {
+	file, err := os.Open(w.statePath())
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	record := stateRecord{}
+
+	if err := gob.NewDecoder(file).Decode(&record); err != nil {
+		return err
+	}
+
+	turn = record.Turn
+	x = record.X
+	y = record.Y
+	z = record.Z
+	health = record.Health
+	spawn = RoomCoord{x: record.SpawnX, y: record.SpawnY, z: record.SpawnZ}
+	inventory = recordedItems(record.Inventory)
+	equipment = recordedItems(record.Equipment)
+	pets = []Entity{}
+
+	for _, e := range record.Pets {
+		pets = append(pets, e.entity())
+	}
+
+	held = record.Held
+	bank = record.Bank
+	timeInRoom = record.TimeInRoom
+	graceUntil = record.GraceUntil
+	return nil
+}
+
+// itemRecord keeps an item's wear along with its name, so a half-broken
+// helmet is still half broken when it comes back.
+type itemRecord struct {
+	Name       string
+	Durability int
+}
+
+func itemRecords(t map[string]Item) []itemRecord {
+	records := []itemRecord{}
+
+	for name, item := range t {
+		records = append(records, itemRecord{Name: name, Durability: item.durability})
+	}
+
+	return records
+}
+
+func recordedItems(records []itemRecord) map[string]Item {
+	t := make(map[string]Item)
+
+	for _, record := range records {
+		item := items[record.Name]
+		item.durability = record.Durability
+		t[record.Name] = item
+	}
+
+	return t
+}
+
+type entityRecord struct {
+	Species  string
+	Health   int
+	Hostile  bool
+	Born     int
+	Love     int
+	Cooldown int
+}
+
+func newEntityRecord(e Entity) entityRecord {
+	return entityRecord{
+		Species:  e.species,
+		Health:   e.health,
+		Hostile:  e.hostile,
+		Born:     e.born,
+		Love:     e.love,
+		Cooldown: e.cooldown,
+	}
+}
+
+func (r entityRecord) entity() Entity {
+	return Entity{
+		species:  r.Species,
+		health:   r.Health,
+		hostile:  r.Hostile,
+		born:     r.Born,
+		love:     r.Love,
+		cooldown: r.Cooldown,
+	}
+}
+
+type roomRecord struct {
+	X           int
+	Y           int
+	Z           int
+	Biome       int
+	Trees       bool
+	Items       []itemRecord
+	Exits       []string
+	Passages    map[string]Passage
+	Walls       map[string]string
+	Doors       map[string]string
+	SmeltOutput string
+	SmeltDone   int
+	Chest       []itemRecord
+	Grave       []itemRecord
+	Crop        bool
+	CropGrowth  int
+	CropUpdated int
+	Entities    []entityRecord
+
+	GraveExpires int
+	TorchOut     int
+	River        string
+	Bridge       bool
+}
+
+func (w *World) saveChunk(c ChunkCoord, chunk *Chunk) error {
+	records := []roomRecord{}
+
+	for coords, room := range chunk.rooms {
+		record := roomRecord{
+			X:           coords.x,
+			Y:           coords.y,
+			Z:           coords.z,
+			Biome:       room.biome,
+			Trees:       room.trees,
+			Exits:       room.getExits(),
+			Passages:    map[string]Passage{},
+			Walls:       room.exits.walls,
+			Doors:       room.exits.doors,
+			SmeltOutput: room.furnace.output,
+			SmeltDone:   room.furnace.done,
+			Crop:        room.crop.planted,
+			CropGrowth:  room.crop.growth,
+			CropUpdated: room.crop.updated,
+
+			GraveExpires: room.graveExpires,
+			TorchOut:     room.torchOut,
+			River:        room.river,
+			Bridge:       room.bridge,
+		}
+
+		for dir, p := range room.exits.kinds {
+			record.Passages[dir] = p
+		}
+
+		record.Items = itemRecords(room.items)
+		record.Chest = itemRecords(room.chest)
+		record.Grave = itemRecords(room.grave)
+
+		for _, e := range room.entities {
+			record.Entities = append(record.Entities, newEntityRecord(e))
+		}
+
+		records = append(records, record)
+	}
+
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(w.chunkPath(c))
+
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(file).Encode(records); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	chunk.dirty = false
+	return nil
+}
+
+func (w *World) loadChunk(c ChunkCoord) (*Chunk, error) {
+	chunk := &Chunk{rooms: make(map[RoomCoord]Room)}
+	file, err := os.Open(w.chunkPath(c))
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return chunk, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	records := []roomRecord{}
+
+	if err := gob.NewDecoder(file).Decode(&records); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		room := Room{
+			biome:   record.Biome,
+			trees:   record.Trees,
+			items:   recordedItems(record.Items),
+			valid:   true,
+			furnace: Smelt{output: record.SmeltOutput, done: record.SmeltDone},
+			crop:    Crop{planted: record.Crop, growth: record.CropGrowth, updated: record.CropUpdated},
+
+			graveExpires: record.GraveExpires,
+			torchOut:     record.TorchOut,
+			river:        record.River,
+			bridge:       record.Bridge,
+		}
+
+		if len(record.Chest) > 0 {
+			room.chest = recordedItems(record.Chest)
+		}
+
+		if len(record.Grave) > 0 {
+			room.grave = recordedItems(record.Grave)
+		}
+
+		coords := RoomCoord{x: record.X, y: record.Y, z: record.Z}
+
+		for _, e := range record.Entities {
+			room.addEntity(e.entity())
+		}
+
+		for _, e := range record.Exits {
+			room.exits.setExit(e, true)
+		}
+
+		for dir, material := range record.Walls {
+			room.exits.setWall(dir, material)
+		}
+
+		for dir, p := range record.Passages {
+			if _, walled := room.exits.wall(dir); walled || room.exits.getExit(dir) {
+				room.exits.setPassage(dir, p)
+			}
+		}
+
+		for dir, name := range record.Doors {
+			room.exits.setDoor(dir, name)
+		}
+
+		chunk.rooms[coords] = room
+	}
+
+	return chunk, nil
+}
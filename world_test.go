@@ -0,0 +1,228 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFloorDiv(t *testing.T) {
+	tests := []struct {
+		a    int
+		b    int
+		want int
+	}{
+		{0, 8, 0},
+		{7, 8, 0},
+		{8, 8, 1},
+		{-1, 8, -1},
+		{-7, 8, -1},
+		{-8, 8, -1},
+		{-9, 8, -2},
+		{-16, 8, -2},
+		{-17, 8, -3},
+	}
+
+	for _, tt := range tests {
+		if got := floorDiv(tt.a, tt.b); got != tt.want {
+			t.Errorf("floorDiv(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRoomChunk(t *testing.T) {
+	tests := []struct {
+		coords RoomCoord
+		want   ChunkCoord
+	}{
+		{RoomCoord{x: 0, y: 0, z: 0}, ChunkCoord{x: 0, z: 0}},
+		{RoomCoord{x: -1, y: -2, z: 7}, ChunkCoord{x: -1, z: 0}},
+		{RoomCoord{x: -8, y: 0, z: -9}, ChunkCoord{x: -1, z: -2}},
+		{RoomCoord{x: 8, y: -3, z: -1}, ChunkCoord{x: 1, z: -1}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.coords.chunk(); got != tt.want {
+			t.Errorf("%v.chunk() = %v, want %v", tt.coords, got, tt.want)
+		}
+	}
+}
+
+func TestChunkRoundTrip(t *testing.T) {
+	world = newWorld(t.TempDir())
+	turn = 100
+
+	helmet := items["an iron helmet"]
+	helmet.durability = 7
+
+	room := Room{
+		biome:   1,
+		trees:   true,
+		items:   map[string]Item{"some wood": items["some wood"], "a chest": items["a chest"], "a river": items["a river"]},
+		valid:   true,
+		furnace: Smelt{output: "some glass", done: 120},
+		chest:   map[string]Item{"an iron helmet": helmet},
+		grave:   map[string]Item{"some coal": items["some coal"]},
+		crop:    Crop{planted: true, growth: 2, updated: 90},
+
+		graveExpires: 150,
+		river:        "north",
+		bridge:       true,
+	}
+
+	room.exits.setExit("east", true)
+	room.exits.setExit("down", true)
+	room.exits.setPassage("down", Ladder)
+	room.exits.setWall("west", "some stone")
+	room.exits.setDoor("east", "a door")
+	room.addEntity(Entity{species: "a pig", health: 3, born: 80, love: 104, cooldown: 110})
+
+	coords := RoomCoord{x: -3, y: 0, z: -12}
+	c := coords.chunk()
+	chunk := &Chunk{rooms: map[RoomCoord]Room{coords: room}}
+
+	if err := world.saveChunk(c, chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := world.loadChunk(c)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := loaded.rooms[coords]
+
+	if !ok {
+		t.Fatalf("room %v missing after load", coords)
+	}
+
+	if !reflect.DeepEqual(got, room) {
+		t.Errorf("room after load = %+v, want %+v", got, room)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	newGame(t)
+	x, y, z = 4, -2, 9
+	turn = 321
+	health = 6
+	spawn = RoomCoord{x: 1, z: -1}
+	give("some coal", "a stone sword")
+
+	wolf := newEntity("a wolf")
+	wolf.health = 3
+	pets = []Entity{wolf}
+
+	if err := world.saveState(); err != nil {
+		t.Fatal(err)
+	}
+
+	x, y, z = 0, 0, 0
+	turn = 0
+	health = maxHealth
+	spawn = RoomCoord{}
+	inventory = map[string]Item{}
+	pets = []Entity{}
+
+	if err := world.loadState(); err != nil {
+		t.Fatal(err)
+	}
+
+	if x != 4 || y != -2 || z != 9 || turn != 321 || health != 6 {
+		t.Errorf("loaded player at %d, %d, %d on turn %d with %d health", x, y, z, turn, health)
+	}
+
+	if spawn != (RoomCoord{x: 1, z: -1}) {
+		t.Errorf("loaded spawn = %v", spawn)
+	}
+
+	if _, ok := inventory["a stone sword"]; !ok || len(inventory) != 3 {
+		t.Errorf("loaded inventory = %v", inventory)
+	}
+
+	if !reflect.DeepEqual(pets, []Entity{wolf}) {
+		t.Errorf("loaded pets = %+v, want %+v", pets, []Entity{wolf})
+	}
+}
+
+// nestedWorld is how rooms were kept before chunks: every room ever visited,
+// held in memory for the rest of the game.
+type nestedWorld map[int]map[int]map[int]Room
+
+func (w nestedWorld) room(c RoomCoord) Room {
+	return w[c.x][c.y][c.z]
+}
+
+func (w nestedWorld) setRoom(c RoomCoord, room Room) {
+	if w[c.x] == nil {
+		w[c.x] = make(map[int]map[int]Room)
+	}
+
+	if w[c.x][c.y] == nil {
+		w[c.x][c.y] = make(map[int]Room)
+	}
+
+	w[c.x][c.y][c.z] = room
+}
+
+func (w nestedWorld) rooms() int {
+	n := 0
+
+	for _, xVal := range w {
+		for _, yVal := range xVal {
+			n += len(yVal)
+		}
+	}
+
+	return n
+}
+
+// BenchmarkWorldWalk walks east across the world, making each room and
+// looking at the rooms around it, and reports how many rooms are still held
+// in memory at the end of the walk.
+func BenchmarkWorldWalk(b *testing.B) {
+	room := Room{
+		items: map[string]Item{"some stone": items["some stone"], "some coal": items["some coal"]},
+		valid: true,
+	}
+
+	b.Run("chunks", func(b *testing.B) {
+		world = newWorld(b.TempDir())
+
+		for i := 0; i < b.N; i++ {
+			coords := RoomCoord{x: i, z: i % chunkSize}
+			world.setRoom(coords, room)
+
+			for _, dir := range directions {
+				dx, dy, dz := dirOffset(dir)
+				world.room(RoomCoord{x: coords.x + dx, y: coords.y + dy, z: coords.z + dz})
+			}
+
+			world.unloadDistant(coords)
+		}
+
+		resident := 0
+
+		for _, chunk := range world.chunks {
+			resident += len(chunk.rooms)
+		}
+
+		b.ReportMetric(float64(resident), "rooms")
+	})
+
+	b.Run("nested maps", func(b *testing.B) {
+		nested := nestedWorld{}
+
+		for i := 0; i < b.N; i++ {
+			coords := RoomCoord{x: i, z: i % chunkSize}
+			nested.setRoom(coords, room)
+
+			for _, dir := range directions {
+				dx, dy, dz := dirOffset(dir)
+				nested.room(RoomCoord{x: coords.x + dx, y: coords.y + dy, z: coords.z + dz})
+			}
+		}
+
+		b.ReportMetric(float64(nested.rooms()), "rooms")
+	})
+}